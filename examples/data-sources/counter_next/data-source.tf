@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+data "counter_next" "release" {
+  semantic_version = {
+    value = counter_semantic_version.this.value
+    previous_triggers = {
+      major = counter_semantic_version.this.major_triggers
+      minor = counter_semantic_version.this.minor_triggers
+    }
+    triggers = {
+      major = { schema = md5(file("schema.json")) }
+      minor = { api = md5(file("api.yaml")) }
+    }
+  }
+}
+
+output "release_label" {
+  value = data.counter_next.release.semantic_version.changed ? "this will release ${data.counter_next.release.semantic_version.next_value}" : "no release"
+}
@@ -0,0 +1,9 @@
+# Returns "build-000412"
+output "padded" {
+  value = provider::counter::format_counter(412, { width = 6, prefix = "build-" })
+}
+
+# Returns "bg"
+output "base36" {
+  value = provider::counter::format_counter(412, { radix = "base36" })
+}
@@ -0,0 +1,4 @@
+# Returns 7
+output "next" {
+  value = provider::counter::monotonic_next(4, 3)
+}
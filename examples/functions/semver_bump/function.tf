@@ -0,0 +1,9 @@
+# Returns "1.3.0"
+output "next_minor" {
+  value = provider::counter::semver_bump("1.2.3", "minor")
+}
+
+# Returns "1.2.4-rc.1+sha.abc123"
+output "hotfix_candidate" {
+  value = provider::counter::semver_bump("1.2.3", "prerelease", { channel = "rc", build_metadata = "sha.abc123" })
+}
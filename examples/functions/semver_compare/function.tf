@@ -0,0 +1,4 @@
+# Returns -1, as a prerelease has a lower precedence than its release.
+output "comparison" {
+  value = provider::counter::semver_compare("1.0.0-rc.1", "1.0.0")
+}
@@ -0,0 +1,4 @@
+# Returns "1.10.0"
+output "latest" {
+  value = provider::counter::semver_max(["1.10.0", "1.2.0", "1.10.0-rc.1"])
+}
@@ -0,0 +1,4 @@
+# Returns { major = 1, minor = 2, patch = 3, prerelease = "beta.1", build = "sha.abc123" }
+output "parsed" {
+  value = provider::counter::semver_parse("1.2.3-beta.1+sha.abc123")
+}
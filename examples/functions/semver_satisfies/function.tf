@@ -0,0 +1,4 @@
+# Returns true
+output "compatible" {
+  value = provider::counter::semver_satisfies("1.4.2", "~> 1.2, != 1.4.0")
+}
@@ -0,0 +1,4 @@
+# Returns ["1.0.0-alpha.2", "1.0.0-alpha.10", "1.0.0", "1.2.0", "1.10.0"]
+output "sorted" {
+  value = provider::counter::semver_sort(["1.10.0", "1.2.0", "1.0.0-alpha.10", "1.0.0-alpha.2", "1.0.0"])
+}
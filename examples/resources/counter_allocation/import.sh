@@ -0,0 +1,2 @@
+# Import the existing allocation of each key.
+terraform import counter_allocation.this web=10,api=20
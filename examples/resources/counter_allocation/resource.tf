@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_allocation" "this" {
+  keys           = keys(var.services)
+  min_value      = 1
+  max_value      = 50000
+  reuse_cooldown = "24h"
+}
+
+resource "downstream" "this" {
+  for_each = var.services
+  priority = counter_allocation.this.allocations[each.key]
+}
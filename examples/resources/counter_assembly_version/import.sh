@@ -0,0 +1,5 @@
+# Import a version at its current value.
+terraform import counter_assembly_version.this 2.1.4512.0
+
+# Optionally provide the profile, overflow and max history so the first plan matches the configuration.
+terraform import counter_assembly_version.this value=2.1.4512.0,profile=msi,overflow=carry
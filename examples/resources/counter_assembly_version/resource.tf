@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_assembly_version" "this" {
+  profile  = "msi"
+  overflow = "carry"
+  minor_triggers = {
+    hash = md5(jsonencode(something_else.this))
+  }
+  build_triggers = {
+    hash = md5(jsonencode(something_else.that))
+  }
+}
+
+resource "downstream" "this" {
+  product_version = counter_assembly_version.this.value
+}
@@ -0,0 +1,5 @@
+# Import a calendar version at its current value, using the default YYYY.0M.MICRO scheme.
+terraform import counter_calendar_version.this 2024.05.3
+
+# Optionally provide the scheme, initial counter value and max history so the first plan matches the configuration.
+terraform import counter_calendar_version.this value=24.5.17.2,scheme=YY.MM.DD.N,micro_initial_value=1
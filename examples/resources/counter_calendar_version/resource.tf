@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_calendar_version" "this" {
+  scheme = "YYYY.0M.MICRO"
+  triggers = {
+    hash = md5(jsonencode(something_else.this))
+  }
+}
+
+resource "downstream" "this" {
+  value = counter_calendar_version.this.value
+}
@@ -0,0 +1,2 @@
+# Import the existing subnet index of each key, as used by cidrsubnet.
+terraform import counter_cidr_allocation.this payments=0,search=3
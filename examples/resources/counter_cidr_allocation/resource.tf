@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_cidr_allocation" "this" {
+  base_cidr     = "10.0.0.0/16"
+  prefix_length = 24
+  keys          = keys(var.teams)
+}
+
+resource "downstream" "this" {
+  for_each   = var.teams
+  cidr_block = counter_cidr_allocation.this.cidrs[each.key]
+}
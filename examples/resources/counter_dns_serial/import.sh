@@ -0,0 +1,5 @@
+# Import the current serial of a zone.
+terraform import counter_dns_serial.this 2024051703
+
+# Optionally provide the max history so the first plan matches the configuration.
+terraform import counter_dns_serial.this value=2024051703,max_history=10
@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_dns_serial" "this" {
+  triggers = {
+    records = md5(jsonencode(var.records))
+  }
+}
+
+resource "downstream" "this" {
+  serial = counter_dns_serial.this.value
+}
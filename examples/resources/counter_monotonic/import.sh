@@ -0,0 +1,5 @@
+# Import a counter at its current value.
+terraform import counter_monotonic.this 412
+
+# Optionally provide the step, initial value and max history so the first plan matches the configuration.
+terraform import counter_monotonic.this value=412,step=2,initial_value=0
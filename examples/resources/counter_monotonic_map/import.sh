@@ -0,0 +1,2 @@
+# Import the current value of each key.
+terraform import counter_monotonic_map.this api=12,web=7
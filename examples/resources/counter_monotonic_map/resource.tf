@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_monotonic_map" "this" {
+  triggers = {
+    for name, service in var.services : name => {
+      hash = md5(jsonencode(service))
+    }
+  }
+}
+
+resource "downstream" "this" {
+  for_each = var.services
+  revision = counter_monotonic_map.this.values[each.key]
+}
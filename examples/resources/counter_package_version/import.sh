@@ -0,0 +1,5 @@
+# Import a version at its current value.
+terraform import counter_package_version.this 1:2.4.1-3
+
+# Optionally provide the format and max history so the first plan matches the configuration.
+terraform import counter_package_version.this value=2.4.1-3.el9,format=rpm
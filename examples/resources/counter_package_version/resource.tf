@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_package_version" "this" {
+  upstream_version = var.upstream_version
+  revision_triggers = {
+    hash = md5(jsonencode(something_else.packaging))
+  }
+}
+
+resource "downstream" "this" {
+  version = counter_package_version.this.value
+}
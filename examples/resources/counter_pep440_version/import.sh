@@ -0,0 +1,5 @@
+# Import a version at its current value. Alternative spellings such as `1.4-rc.2` are normalised.
+terraform import counter_pep440_version.this 1.4.0rc2
+
+# Optionally provide the initial values and max history so the first plan matches the configuration.
+terraform import counter_pep440_version.this value=1!2.0.0.post1,major_initial_value=0
@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_pep440_version" "this" {
+  pre_phase = "rc"
+  minor_triggers = {
+    hash = md5(jsonencode(something_else.this))
+  }
+  pre_triggers = {
+    hash = md5(jsonencode(something_else.that))
+  }
+}
+
+resource "downstream" "this" {
+  version = counter_pep440_version.this.value
+}
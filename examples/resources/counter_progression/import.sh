@@ -0,0 +1,2 @@
+# Import a progression at the index of its current stage.
+terraform import counter_progression.this 2
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_progression" "this" {
+  stages = [1, 5, 25, 50, 100]
+  triggers = {
+    promote = var.promote
+  }
+  rollback_triggers = {
+    rollback = var.rollback
+  }
+  reset_triggers = {
+    release = var.release
+  }
+}
+
+resource "downstream" "this" {
+  canary_weight = counter_progression.this.current_stage
+}
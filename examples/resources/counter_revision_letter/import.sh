@@ -0,0 +1,5 @@
+# Import a revision at its current value.
+terraform import counter_revision_letter.this C.2
+
+# Optionally provide the initial letter and max history so the first plan matches the configuration.
+terraform import counter_revision_letter.this value=C.2,initial_letter=A
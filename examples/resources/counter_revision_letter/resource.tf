@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_revision_letter" "this" {
+  excluded_letters = ["I", "O", "Q", "S", "X", "Z"]
+  triggers = {
+    hash = md5(jsonencode(something_else.this))
+  }
+  sub_revision_triggers = {
+    hash = md5(jsonencode(something_else.that))
+  }
+}
+
+resource "downstream" "this" {
+  revision = counter_revision_letter.this.value
+}
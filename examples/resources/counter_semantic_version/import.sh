@@ -0,0 +1,8 @@
+# Import a semantic version at its current value.
+terraform import counter_semantic_version.this 3.7.2
+
+# Optionally provide the initial values and max history so the first plan matches the configuration.
+terraform import counter_semantic_version.this value=3.7.2,major_initial_value=0
+
+# Build metadata in the version is imported as build_metadata.
+terraform import counter_semantic_version.this 3.7.2+sha.abc123
@@ -0,0 +1,2 @@
+# Import a toggle at its active slot.
+terraform import counter_toggle.this green
@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    counter = {
+      source = "terraform-provider-counter/counter"
+    }
+  }
+}
+
+resource "counter_toggle" "this" {
+  triggers = {
+    release = var.release
+  }
+}
+
+resource "downstream" "this" {
+  live_target  = counter_toggle.this.active
+  stage_target = counter_toggle.this.inactive
+}
@@ -0,0 +1,429 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AllocationResource{}
+var _ resource.ResourceWithConfigure = &AllocationResource{}
+var _ resource.ResourceWithModifyPlan = &AllocationResource{}
+var _ resource.ResourceWithImportState = &AllocationResource{}
+var _ resource.ResourceWithValidateConfig = &AllocationResource{}
+
+func NewAllocationResource() resource.Resource {
+	return &AllocationResource{}
+}
+
+type AllocationResource struct {
+	// now returns the current time, as configured by the provider.
+	now func() time.Time
+}
+
+func (a *AllocationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_allocation"
+}
+
+func (a *AllocationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Allocates a unique integer to each of a set of keys, such as listener rule priorities, VLAN IDs or port numbers. New keys receive the lowest free integer in the range, and existing keys are never renumbered when others are added or removed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"allocations": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "The integer allocated to each key.",
+			},
+			"keys": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "The keys which need an integer. Removing a key frees its integer.",
+			},
+			"min_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "The lowest integer to allocate. Defaults to 1.",
+			},
+			"max_value": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The highest integer to allocate. The plan fails when a new key can't be allocated an integer. Unlimited by default.",
+			},
+			"excluded_values": schema.SetAttribute{
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				MarkdownDescription: "Integers in the range which are never allocated, such as reserved VLAN IDs. Changing the range or exclusions never renumbers existing keys.",
+			},
+			"reuse_cooldown": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long a freed integer is held back before another key can be allocated it, as a duration such as `24h`. A key which is added back during its cooldown receives its previous integer. Freed integers can be reused straight away by default. The cooldown relies on the release being recorded in `history`.",
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of events this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"history": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "A list of the allocations and releases that this resource has made, for auditing past assignments. Each entry has the `key`, its `value`, an `action` of `allocated` or `released` and the `timestamp` of the change.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed: true,
+						},
+						"value": schema.Int64Attribute{
+							Computed: true,
+						},
+						"action": schema.StringAttribute{
+							Computed: true,
+						},
+						"timestamp": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (a *AllocationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*counterProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *counterProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	a.now = data.now
+}
+
+func (a *AllocationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data allocationModelV0
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.MinValue.IsNull() && !data.MinValue.IsUnknown() && !data.MaxValue.IsNull() && !data.MaxValue.IsUnknown() && data.MinValue.ValueInt64() > data.MaxValue.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("max_value"), "Invalid Allocation Range", fmt.Sprintf("The max_value %d is less than the min_value %d.", data.MaxValue.ValueInt64(), data.MinValue.ValueInt64()))
+	}
+	if !data.ReuseCooldown.IsNull() && !data.ReuseCooldown.IsUnknown() {
+		if _, err := parseReuseCooldown(data.ReuseCooldown.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("reuse_cooldown"), "Invalid Reuse Cooldown", fmt.Sprintf("The reuse cooldown %s.", err))
+		}
+	}
+}
+
+func (a *AllocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data allocationModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	// The allocations are only settled here if they couldn't be while planning.
+	if data.Allocations.IsUnknown() {
+		data.History = types.ListNull(allocationHistoryEntryType)
+		resp.Diagnostics.Append(a.allocate(ctx, types.MapValueMust(types.Int64Type, nil), &data)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a *AllocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (a *AllocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data allocationModelV0
+	var prior allocationModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The allocations are only settled here if they couldn't be while planning.
+	if data.Allocations.IsUnknown() {
+		data.History = prior.History
+		resp.Diagnostics.Append(a.allocate(ctx, prior.Allocations, &data)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a *AllocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (a *AllocationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data allocationModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Which integers are allocated can't be known until the keys and range are, for example when they are derived
+	// from a resource which is being replaced. Create and Update settle the allocations once the inputs are known.
+	if !setFullyKnown(data.Keys) || data.MinValue.IsUnknown() || data.MaxValue.IsUnknown() || !setFullyKnown(data.ExcludedValues) ||
+		data.ReuseCooldown.IsUnknown() || data.MaxHistory.IsUnknown() {
+		data.Allocations = types.MapUnknown(types.Int64Type)
+		data.History = types.ListUnknown(allocationHistoryEntryType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+		return
+	}
+
+	prior := types.MapValueMust(types.Int64Type, nil)
+	data.History = types.ListNull(allocationHistoryEntryType)
+	if !req.State.Raw.IsNull() {
+		var state allocationModelV0
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		prior = state.Allocations
+		data.History = state.History
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(a.allocate(ctx, prior, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+// allocate releases the integers of keys which were removed and allocates integers to keys which were added, in key
+// order, recording each in the history which data holds from the prior state.
+func (a *AllocationResource) allocate(ctx context.Context, prior types.Map, data *allocationModelV0) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var keys []string
+	var excluded []int64
+	diags.Append(data.Keys.ElementsAs(ctx, &keys, false)...)
+	if !data.ExcludedValues.IsNull() {
+		diags.Append(data.ExcludedValues.ElementsAs(ctx, &excluded, false)...)
+	}
+	allocations := map[string]int64{}
+	diags.Append(prior.ElementsAs(ctx, &allocations, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	slices.Sort(keys)
+	minimum, maximum := data.MinValue.ValueInt64(), int64(math.MaxInt64)
+	if !data.MaxValue.IsNull() {
+		maximum = data.MaxValue.ValueInt64()
+	}
+	now := currentTime(a.now)
+	timestamp := types.StringValue(now.Format(time.RFC3339))
+
+	for _, key := range slices.Sorted(maps.Keys(allocations)) {
+		if !slices.Contains(keys, key) {
+			data.History = appendAndTruncate(ctx, data.History, a.createHistoryEntry(key, allocations[key], "released", timestamp), data.MaxHistory.ValueInt64())
+			delete(allocations, key)
+		}
+	}
+
+	// Integers released within the cooldown are held back for the key which released them, and a key which returns
+	// reclaims the integer it released most recently.
+	cooling := map[int64]string{}
+	lastReleased := map[string]int64{}
+	if !data.ReuseCooldown.IsNull() {
+		cooldown, _ := parseReuseCooldown(data.ReuseCooldown.ValueString())
+		for _, element := range data.History.Elements() {
+			entry := element.(basetypes.ObjectValue).Attributes()
+			value := entry["value"].(types.Int64).ValueInt64()
+			released, err := time.Parse(time.RFC3339, entry["timestamp"].(types.String).ValueString())
+			if entry["action"].(types.String).ValueString() == "released" && err == nil && released.Add(cooldown).After(now) {
+				cooling[value] = entry["key"].(types.String).ValueString()
+				lastReleased[cooling[value]] = value
+			} else {
+				delete(cooling, value)
+			}
+		}
+	}
+
+	used := map[int64]bool{}
+	for key, value := range allocations {
+		used[value] = true
+		if value < minimum || value > maximum || slices.Contains(excluded, value) {
+			diags.AddAttributeWarning(
+				path.Root("allocations").AtMapKey(key),
+				"Allocation Outside Range",
+				fmt.Sprintf("The key %q keeps %d, which is outside the range or excluded, as existing keys are never renumbered.", key, value),
+			)
+		}
+	}
+	for _, key := range keys {
+		if _, ok := allocations[key]; ok {
+			continue
+		}
+		value, ok := lowestFreeAllocation(key, minimum, maximum, excluded, used, cooling, lastReleased)
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("keys"),
+				"Allocation Range Exhausted",
+				fmt.Sprintf("There is no free integer between %d and %d for the key %q.", minimum, maximum, key),
+			)
+			return diags
+		}
+		allocations[key] = value
+		used[value] = true
+		data.History = appendAndTruncate(ctx, data.History, a.createHistoryEntry(key, value, "allocated", timestamp), data.MaxHistory.ValueInt64())
+	}
+
+	values := map[string]attr.Value{}
+	for key, value := range allocations {
+		values[key] = types.Int64Value(value)
+	}
+	data.Allocations = types.MapValueMust(types.Int64Type, values)
+	return diags
+}
+
+// lowestFreeAllocation returns the integer for a new key, which is the one it most recently released if that is still
+// cooling down for it, or otherwise the lowest integer in the range which isn't excluded, used or cooling down.
+func lowestFreeAllocation(key string, minimum int64, maximum int64, excluded []int64, used map[int64]bool, cooling map[int64]string, lastReleased map[string]int64) (int64, bool) {
+	if value, ok := lastReleased[key]; ok && cooling[value] == key && !used[value] && value >= minimum && value <= maximum && !slices.Contains(excluded, value) {
+		return value, true
+	}
+	for value := minimum; value <= maximum; value++ {
+		if _, ok := cooling[value]; !ok && !used[value] && !slices.Contains(excluded, value) {
+			return value, true
+		}
+		if value == math.MaxInt64 {
+			break
+		}
+	}
+	return 0, false
+}
+
+// parseReuseCooldown parses a duration such as `24h`, which must not be negative.
+func parseReuseCooldown(value string) (time.Duration, error) {
+	cooldown, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a duration such as `24h`", value)
+	}
+	if cooldown < 0 {
+		return 0, fmt.Errorf("%q must not be negative", value)
+	}
+	return cooldown, nil
+}
+
+func (a *AllocationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	now := currentTime(a.now)
+	timestamp := types.StringValue(now.Format(time.RFC3339))
+	data := allocationModelV0{
+		Id:             types.StringValue(uuid.New().String()),
+		MinValue:       types.Int64Value(1),
+		MaxValue:       types.Int64Null(),
+		ExcludedValues: types.SetNull(types.Int64Type),
+		ReuseCooldown:  types.StringNull(),
+		MaxHistory:     types.Int64Value(defaultMaxHistory),
+		History:        types.ListNull(allocationHistoryEntryType),
+	}
+
+	// The identifier lists the existing allocations, such as `web=10,api=20`.
+	keys := []attr.Value{}
+	allocations := map[string]attr.Value{}
+	for _, pair := range strings.Split(req.ID, ",") {
+		key, raw, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		value, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if !found || key == "" || err != nil {
+			resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: expected `key=integer`, got %q. Expected allocations such as `web=10,api=20`.", req.ID, pair))
+			return
+		}
+		if _, duplicate := allocations[key]; duplicate {
+			resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: the key %q is specified more than once.", req.ID, key))
+			return
+		}
+		for other, allocated := range allocations {
+			if allocated.(types.Int64).ValueInt64() == value {
+				resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: the keys %q and %q are both allocated %d.", req.ID, other, key, value))
+				return
+			}
+		}
+		keys = append(keys, types.StringValue(key))
+		allocations[key] = types.Int64Value(value)
+		data.History = appendAndTruncate(ctx, data.History, a.createHistoryEntry(key, value, "allocated", timestamp), data.MaxHistory.ValueInt64())
+	}
+	data.Keys = types.SetValueMust(types.StringType, keys)
+	data.Allocations = types.MapValueMust(types.Int64Type, allocations)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+var allocationHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"key":       types.StringType,
+		"value":     types.Int64Type,
+		"action":    types.StringType,
+		"timestamp": types.StringType,
+	},
+}
+
+func (a *AllocationResource) createHistoryEntry(key string, value int64, action string, timestamp types.String) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		allocationHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"key":       types.StringValue(key),
+			"value":     types.Int64Value(value),
+			"action":    types.StringValue(action),
+			"timestamp": timestamp,
+		},
+	)
+}
+
+type allocationModelV0 struct {
+	Id             types.String `tfsdk:"id"`
+	Allocations    types.Map    `tfsdk:"allocations"`
+	Keys           types.Set    `tfsdk:"keys"`
+	MinValue       types.Int64  `tfsdk:"min_value"`
+	MaxValue       types.Int64  `tfsdk:"max_value"`
+	ExcludedValues types.Set    `tfsdk:"excluded_values"`
+	ReuseCooldown  types.String `tfsdk:"reuse_cooldown"`
+	MaxHistory     types.Int64  `tfsdk:"max_history"`
+	History        types.List   `tfsdk:"history"`
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func allocationStep(fixedTime string, keys string) string {
+	return `
+		provider counter {
+			fixed_time = "` + fixedTime + `"
+		}
+
+		resource counter_allocation this {
+			keys            = [` + keys + `]
+			min_value       = 100
+			excluded_values = [101]
+			reuse_cooldown  = "24h"
+		}
+	`
+}
+
+func TestAccAllocationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: allocationStep("2024-05-17T09:30:00Z", `"web", "api", "admin"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.admin", "100"),
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.api", "102"),
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.web", "103"),
+					resource.TestCheckResourceAttr("counter_allocation.this", "history.#", "3"),
+				),
+			},
+			// Removing a key releases its integer without renumbering the others
+			{
+				Config: allocationStep("2024-05-17T10:00:00Z", `"web", "admin"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.%", "2"),
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.web", "103"),
+					resource.TestCheckResourceAttr("counter_allocation.this", "history.3.key", "api"),
+					resource.TestCheckResourceAttr("counter_allocation.this", "history.3.action", "released"),
+					resource.TestCheckResourceAttr("counter_allocation.this", "history.3.timestamp", "2024-05-17T10:00:00Z"),
+				),
+			},
+			// The released integer is held back during the cooldown
+			{
+				Config: allocationStep("2024-05-17T11:00:00Z", `"web", "admin", "metrics"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.metrics", "104"),
+				),
+			},
+			{
+				Config: allocationStep("2024-05-18T11:00:00Z", `"web", "admin", "metrics", "status"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.status", "102"),
+					resource.TestCheckResourceAttr("counter_allocation.this", "history.#", "6"),
+				),
+			},
+		},
+	})
+}
+
+func reclaimStep(fixedTime string, keys string, excluded string) string {
+	return `
+		provider counter {
+			fixed_time = "` + fixedTime + `"
+		}
+
+		resource counter_allocation this {
+			keys            = [` + keys + `]
+			min_value       = 100
+			excluded_values = [` + excluded + `]
+			reuse_cooldown  = "24h"
+		}
+	`
+}
+
+func TestAccAllocationResourceReclaim(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: reclaimStep("2024-05-17T09:00:00Z", `"api"`, "101"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.api", "100"),
+				),
+			},
+			{
+				Config: reclaimStep("2024-05-17T10:00:00Z", ``, "101"),
+			},
+			// The integer released by the key is excluded, so it returns with another one
+			{
+				Config: reclaimStep("2024-05-17T11:00:00Z", `"api"`, "100, 101"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.api", "102"),
+				),
+			},
+			{
+				Config: reclaimStep("2024-05-17T12:00:00Z", ``, "101"),
+			},
+			// Both integers are cooling down for the key, which reclaims the one it released most recently
+			{
+				Config: reclaimStep("2024-05-17T13:00:00Z", `"api"`, "101"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.api", "102"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAllocationResourceExhausted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource counter_allocation this {
+						keys      = ["web", "api"]
+						max_value = 1
+					}
+				`,
+				ExpectError: regexp.MustCompile(`no free integer between 1 and 1 for the key "web"`),
+			},
+		},
+	})
+}
+
+func TestAccAllocationResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: allocationStep("2024-05-17T09:30:00Z", `"web", "api"`),
+			},
+			{
+				Config:             allocationStep("2024-05-17T09:30:00Z", `"web", "api"`),
+				ResourceName:       "counter_allocation.this",
+				ImportState:        true,
+				ImportStateId:      "web=120,api=110",
+				ImportStatePersist: true,
+			},
+			{
+				Config: allocationStep("2024-05-17T09:30:00Z", `"web", "api", "admin"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.web", "120"),
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.api", "110"),
+					resource.TestCheckResourceAttr("counter_allocation.this", "allocations.admin", "100"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,562 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"strconv"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AssemblyVersionResource{}
+var _ resource.ResourceWithModifyPlan = &AssemblyVersionResource{}
+var _ resource.ResourceWithImportState = &AssemblyVersionResource{}
+var _ resource.ResourceWithConfigValidators = &AssemblyVersionResource{}
+var _ resource.ResourceWithValidateConfig = &AssemblyVersionResource{}
+
+// assemblyVersionComponents are the names of the version components, from the highest to the lowest.
+var assemblyVersionComponents = []string{"major", "minor", "build", "revision"}
+
+// assemblyVersionProfiles are the largest value of each component accepted by .NET assembly versions, which reserve
+// 65535, and by Windows Installer product versions, which limit the major and minor versions to 255.
+var assemblyVersionProfiles = map[string][4]int64{
+	"assembly": {65534, 65534, 65534, 65534},
+	"msi":      {255, 255, 65534, 65534},
+}
+
+func NewAssemblyVersionResource() resource.Resource {
+	return &AssemblyVersionResource{}
+}
+
+type AssemblyVersionResource struct {
+}
+
+func (a AssemblyVersionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assembly_version"
+}
+
+func (a AssemblyVersionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A four-part `<major>.<minor>.<build>.<revision>` version for .NET assemblies and Windows Installer packages, whose components increment according to the configured triggers within the limits of the target platform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The version as a string in `<major>.<minor>.<build>.<revision>` form.",
+			},
+			"major_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current major version number.",
+			},
+			"minor_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current minor version number.",
+			},
+			"build_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current build number.",
+			},
+			"revision_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current revision number.",
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of versions this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"history": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "A list of versions that this resource has produced.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+						"major_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"minor_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"build_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"revision_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"major_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "The initial major version number. Must not be negative or exceed the limit of the component. Defaults to 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"minor_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The initial minor version number. Must not be negative or exceed the limit of the component. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"build_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The initial build number. Must not be negative or exceed the limit of the component. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"revision_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The initial revision number. Must not be negative or exceed the limit of the component. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"major_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the major version number to increment when any of the values change.",
+			},
+			"minor_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the minor version number to increment when any of the values change.",
+			},
+			"build_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the build number to increment when any of the values change.",
+			},
+			"revision_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the revision number to increment when any of the values change.",
+			},
+			"profile": schema.StringAttribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             stringdefault.StaticString("assembly"),
+				MarkdownDescription: "The platform whose limits apply to each component. With `assembly` every component is at most 65534, as required for .NET `AssemblyVersion`, and with `msi` the major and minor versions are also at most 255, as required for Windows Installer `ProductVersion`. Defaults to `assembly`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("assembly", "msi"),
+				},
+			},
+			"max_values": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				MarkdownDescription: "Lower limits for individual components than `profile` sets, keyed by `major`, `minor`, `build` or `revision`.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(assemblyVersionComponents...)),
+					mapvalidator.ValueInt64sAre(int64validator.Between(0, 65534)),
+				},
+			},
+			"overflow": schema.StringAttribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             stringdefault.StaticString("error"),
+				MarkdownDescription: "What happens when a component would exceed its limit. With `error` the plan fails, and with `carry` the component starts over at 0 and the next higher component increments instead, such as `1.2.3.65534` to `1.2.4.0`. The major version never carries. Defaults to `error`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("error", "carry"),
+				},
+			},
+		},
+	}
+}
+
+func (a AssemblyVersionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		triggersConfigured("major_triggers", "minor_triggers", "build_triggers", "revision_triggers"),
+	}
+}
+
+func (a AssemblyVersionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data assemblyVersionModelV0
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Profile.IsUnknown() || !mapFullyKnown(data.MaxValues) {
+		return
+	}
+	if data.Profile.IsNull() {
+		data.Profile = types.StringValue("assembly")
+	}
+
+	limits, ok := a.limits(data)
+	if !ok {
+		return
+	}
+	for i, initial := range []types.Int64{data.MajorInitialValue, data.MinorInitialValue, data.BuildInitialValue, data.RevisionInitialValue} {
+		if initial.IsNull() || initial.IsUnknown() || initial.ValueInt64() <= limits[i] {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(assemblyVersionComponents[i]+"_initial_value"),
+			"Initial Value Exceeds Limit",
+			fmt.Sprintf("The initial %s component %d exceeds its limit of %d.", assemblyVersionComponents[i], initial.ValueInt64(), limits[i]),
+		)
+	}
+}
+
+func (a AssemblyVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data assemblyVersionModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	resp.Diagnostics.Append(a.initialise(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a AssemblyVersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (a AssemblyVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	a.lifecycle().update(ctx, req, resp)
+}
+
+func (a AssemblyVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (a AssemblyVersionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	a.lifecycle().modifyPlan(ctx, req, resp)
+}
+
+func (a AssemblyVersionResource) lifecycle() triggeredLifecycle[assemblyVersionModelV0] {
+	return triggeredLifecycle[assemblyVersionModelV0]{
+		known:      func(data assemblyVersionModelV0) bool { return !a.hasUnknownInputs(data) },
+		unknown:    a.setUnknownInputsVersion,
+		initialise: a.initialise,
+		advance:    a.advance,
+	}
+}
+
+// setUnknownInputsVersion plans the version of a new resource, which starts at the initial version whatever the
+// triggers are, once the initial values are known.
+func (a AssemblyVersionResource) setUnknownInputsVersion(data *assemblyVersionModelV0, creation bool) {
+	if creation && a.initialVersionKnown(*data) {
+		a.setVersion(data, a.initialVersion(*data))
+	} else {
+		a.setUnknownVersion(data)
+	}
+	data.History = types.ListUnknown(assemblyVersionHistoryEntryType)
+}
+
+func (a AssemblyVersionResource) hasUnknownInputs(data assemblyVersionModelV0) bool {
+	return !mapFullyKnown(data.MajorTriggers) || !mapFullyKnown(data.MinorTriggers) || !mapFullyKnown(data.BuildTriggers) ||
+		!mapFullyKnown(data.RevisionTriggers) || data.Profile.IsUnknown() || !mapFullyKnown(data.MaxValues) ||
+		data.Overflow.IsUnknown() || data.MaxHistory.IsUnknown() || !a.initialVersionKnown(data)
+}
+
+func (a AssemblyVersionResource) initialVersionKnown(data assemblyVersionModelV0) bool {
+	return !data.MajorInitialValue.IsUnknown() && !data.MinorInitialValue.IsUnknown() &&
+		!data.BuildInitialValue.IsUnknown() && !data.RevisionInitialValue.IsUnknown()
+}
+
+func (a AssemblyVersionResource) initialVersion(data assemblyVersionModelV0) [4]int64 {
+	return [4]int64{
+		data.MajorInitialValue.ValueInt64(),
+		data.MinorInitialValue.ValueInt64(),
+		data.BuildInitialValue.ValueInt64(),
+		data.RevisionInitialValue.ValueInt64(),
+	}
+}
+
+// limits returns the largest value of each component, which is the lower of the profile limit and max_values. The
+// max_values must be fully known, as a limit which isn't known yet would otherwise be ignored.
+func (a AssemblyVersionResource) limits(data assemblyVersionModelV0) ([4]int64, bool) {
+	limits, ok := assemblyVersionProfiles[data.Profile.ValueString()]
+	if !ok {
+		return limits, false
+	}
+	for i, component := range assemblyVersionComponents {
+		if value, ok := data.MaxValues.Elements()[component].(types.Int64); ok && !value.IsNull() {
+			limits[i] = min(limits[i], value.ValueInt64())
+		}
+	}
+	return limits, true
+}
+
+// initialise sets the version and history of a resource which is being created.
+func (a AssemblyVersionResource) initialise(ctx context.Context, data *assemblyVersionModelV0) diag.Diagnostics {
+	a.setVersion(data, a.initialVersion(*data))
+	data.History = appendAndTruncate(ctx, types.ListNull(assemblyVersionHistoryEntryType), a.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return nil
+}
+
+// advance sets the version and history from the prior state according to the triggers which changed. Only the highest
+// changed component increments, and the lower components start over at 0.
+func (a AssemblyVersionResource) advance(ctx context.Context, prior assemblyVersionModelV0, data *assemblyVersionModelV0, imported bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	current, err := parseAssemblyVersion(prior.Value.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Resource State", fmt.Sprintf("Unable to parse the current version: %s.", err))
+		return diags
+	}
+	a.setVersion(data, current)
+	data.History = prior.History
+
+	if imported {
+		data.History = replaceLastAndTruncate(ctx, prior.History, a.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+		return diags
+	}
+
+	changed := -1
+	for i, triggers := range [][2]types.Map{
+		{prior.MajorTriggers, data.MajorTriggers},
+		{prior.MinorTriggers, data.MinorTriggers},
+		{prior.BuildTriggers, data.BuildTriggers},
+		{prior.RevisionTriggers, data.RevisionTriggers},
+	} {
+		if !triggers[0].Equal(triggers[1]) {
+			changed = i
+			break
+		}
+	}
+	if changed < 0 {
+		return diags
+	}
+
+	limits, _ := a.limits(*data)
+	next, err := incrementAssemblyVersion(current, changed, limits, data.Overflow.ValueString() == "carry")
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(assemblyVersionComponents[changed]+"_triggers"),
+			"Version Component Overflow",
+			fmt.Sprintf("Unable to increment the version %s: %s.", prior.Value.ValueString(), err),
+		)
+		return diags
+	}
+
+	a.setVersion(data, next)
+	data.History = appendAndTruncate(ctx, prior.History, a.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return diags
+}
+
+// incrementAssemblyVersion increments a component, starting the lower components over at 0. A component which would
+// exceed its limit is an error unless carry is set, in which case it starts over at 0 and the next higher component
+// increments instead.
+func incrementAssemblyVersion(version [4]int64, component int, limits [4]int64, carry bool) ([4]int64, error) {
+	next := version
+	next[component]++
+	for i := component + 1; i < len(next); i++ {
+		next[i] = 0
+	}
+	for i := component; i > 0 && carry && next[i] > limits[i]; i-- {
+		next[i] = 0
+		next[i-1]++
+	}
+	for i, value := range next {
+		if value > limits[i] {
+			if carry && i == 0 {
+				return next, fmt.Errorf("the major component would exceed its limit of %d, and the major component never carries", limits[i])
+			}
+			return next, fmt.Errorf("the %s component would exceed its limit of %d. Set overflow to `carry` to increment the next higher component instead", assemblyVersionComponents[i], limits[i])
+		}
+	}
+	return next, nil
+}
+
+// parseAssemblyVersion parses a version in `<major>.<minor>.<build>.<revision>` form.
+func parseAssemblyVersion(value string) ([4]int64, error) {
+	var version [4]int64
+	parts := strings.Split(value, ".")
+	if len(parts) != len(version) {
+		return version, fmt.Errorf("%q is not in `<major>.<minor>.<build>.<revision>` form", value)
+	}
+	for i, part := range parts {
+		number, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || number < 0 {
+			return version, fmt.Errorf("%q is not in `<major>.<minor>.<build>.<revision>` form", value)
+		}
+		version[i] = number
+	}
+	return version, nil
+}
+
+func (a AssemblyVersionResource) setVersion(data *assemblyVersionModelV0, version [4]int64) {
+	data.Value = types.StringValue(fmt.Sprintf("%d.%d.%d.%d", version[0], version[1], version[2], version[3]))
+	data.MajorValue = types.Int64Value(version[0])
+	data.MinorValue = types.Int64Value(version[1])
+	data.BuildValue = types.Int64Value(version[2])
+	data.RevisionValue = types.Int64Value(version[3])
+}
+
+func (a AssemblyVersionResource) setUnknownVersion(data *assemblyVersionModelV0) {
+	data.Value = types.StringUnknown()
+	data.MajorValue = types.Int64Unknown()
+	data.MinorValue = types.Int64Unknown()
+	data.BuildValue = types.Int64Unknown()
+	data.RevisionValue = types.Int64Unknown()
+}
+
+func (a AssemblyVersionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := parseImportId(req.ID, "value", "value", "profile", "overflow", "max_history")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: %s. Expected a version such as `2.1.4512.0`, or `value=2.1.4512.0,profile=msi`.", req.ID, err))
+		return
+	}
+
+	data := assemblyVersionModelV0{
+		Id:                   types.StringValue(uuid.New().String()),
+		MaxHistory:           types.Int64Value(defaultMaxHistory),
+		MajorInitialValue:    types.Int64Value(1),
+		MinorInitialValue:    types.Int64Value(0),
+		BuildInitialValue:    types.Int64Value(0),
+		RevisionInitialValue: types.Int64Value(0),
+		MajorTriggers:        types.MapNull(types.StringType),
+		MinorTriggers:        types.MapNull(types.StringType),
+		BuildTriggers:        types.MapNull(types.StringType),
+		RevisionTriggers:     types.MapNull(types.StringType),
+		Profile:              types.StringValue("assembly"),
+		MaxValues:            types.MapNull(types.Int64Type),
+		Overflow:             types.StringValue("error"),
+	}
+	if profile, ok := values["profile"]; ok {
+		data.Profile = types.StringValue(profile)
+	}
+	if overflow, ok := values["overflow"]; ok {
+		data.Overflow = types.StringValue(overflow)
+	}
+	if _, ok := assemblyVersionProfiles[data.Profile.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("profile"), "Invalid Import Identifier", fmt.Sprintf("The profile %q must be `assembly` or `msi`.", data.Profile.ValueString()))
+	}
+	if overflow := data.Overflow.ValueString(); overflow != "error" && overflow != "carry" {
+		resp.Diagnostics.AddAttributeError(path.Root("overflow"), "Invalid Import Identifier", fmt.Sprintf("The overflow %q must be `error` or `carry`.", overflow))
+	}
+	if raw, ok := values["max_history"]; ok {
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || number < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_history"), "Invalid Import Identifier", fmt.Sprintf("The value %q for max_history must be a whole number of at least 1.", raw))
+		}
+		data.MaxHistory = types.Int64Value(number)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version, err := parseAssemblyVersion(values["value"])
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid Import Identifier", fmt.Sprintf("The version %s.", err))
+		return
+	}
+	limits, _ := a.limits(data)
+	for i, value := range version {
+		if value > limits[i] {
+			resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid Import Identifier", fmt.Sprintf("The %s component %d exceeds its limit of %d.", assemblyVersionComponents[i], value, limits[i]))
+			return
+		}
+	}
+	a.setVersion(&data, version)
+	data.History = appendAndTruncate(ctx, types.ListNull(assemblyVersionHistoryEntryType), a.createHistoryEntry(data), data.MaxHistory.ValueInt64())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
+}
+
+var assemblyVersionHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":             types.StringType,
+		"major_triggers":    types.MapType{ElemType: types.StringType},
+		"minor_triggers":    types.MapType{ElemType: types.StringType},
+		"build_triggers":    types.MapType{ElemType: types.StringType},
+		"revision_triggers": types.MapType{ElemType: types.StringType},
+	},
+}
+
+func (a AssemblyVersionResource) createHistoryEntry(data assemblyVersionModelV0) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		assemblyVersionHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"value":             data.Value,
+			"major_triggers":    data.MajorTriggers,
+			"minor_triggers":    data.MinorTriggers,
+			"build_triggers":    data.BuildTriggers,
+			"revision_triggers": data.RevisionTriggers,
+		},
+	)
+}
+
+type assemblyVersionModelV0 struct {
+	Id                   types.String `tfsdk:"id"`
+	Value                types.String `tfsdk:"value"`
+	MajorValue           types.Int64  `tfsdk:"major_value"`
+	MinorValue           types.Int64  `tfsdk:"minor_value"`
+	BuildValue           types.Int64  `tfsdk:"build_value"`
+	RevisionValue        types.Int64  `tfsdk:"revision_value"`
+	MaxHistory           types.Int64  `tfsdk:"max_history"`
+	History              types.List   `tfsdk:"history"`
+	MajorInitialValue    types.Int64  `tfsdk:"major_initial_value"`
+	MinorInitialValue    types.Int64  `tfsdk:"minor_initial_value"`
+	BuildInitialValue    types.Int64  `tfsdk:"build_initial_value"`
+	RevisionInitialValue types.Int64  `tfsdk:"revision_initial_value"`
+	MajorTriggers        types.Map    `tfsdk:"major_triggers"`
+	MinorTriggers        types.Map    `tfsdk:"minor_triggers"`
+	BuildTriggers        types.Map    `tfsdk:"build_triggers"`
+	RevisionTriggers     types.Map    `tfsdk:"revision_triggers"`
+	Profile              types.String `tfsdk:"profile"`
+	MaxValues            types.Map    `tfsdk:"max_values"`
+	Overflow             types.String `tfsdk:"overflow"`
+}
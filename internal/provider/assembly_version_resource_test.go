@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"regexp"
+	"testing"
+)
+
+func assemblyVersionStep(build string, revision string, overflow string) string {
+	return `
+		resource counter_assembly_version this {
+			revision_initial_value = 65533
+			overflow               = "` + overflow + `"
+			build_triggers = {
+				hash = "` + build + `"
+			}
+			revision_triggers = {
+				hash = "` + revision + `"
+			}
+		}
+	`
+}
+
+func TestAccAssemblyVersionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: assemblyVersionStep("potatoes", "potatoes", "error"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "value", "1.0.0.65533"),
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "revision_value", "65533"),
+				),
+			},
+			{
+				Config: assemblyVersionStep("potatoes", "eggs", "error"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "value", "1.0.0.65534"),
+				),
+			},
+			// The revision can't exceed 65534
+			{
+				Config:      assemblyVersionStep("potatoes", "bacon", "error"),
+				ExpectError: regexp.MustCompile(`revision component would exceed its limit of 65534`),
+			},
+			// Carrying increments the build number instead
+			{
+				Config: assemblyVersionStep("potatoes", "bacon", "carry"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "value", "1.0.1.0"),
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "build_value", "1"),
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "history.#", "3"),
+				),
+			},
+			{
+				Config: assemblyVersionStep("eggs", "bacon", "carry"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "value", "1.0.2.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssemblyVersionResourceProfile(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource counter_assembly_version this {
+						profile             = "msi"
+						minor_initial_value = 256
+						minor_triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`initial minor component 256 exceeds its limit of 255`),
+			},
+			{
+				Config: `
+					resource counter_assembly_version this {
+						profile             = "msi"
+						minor_initial_value = 255
+						overflow            = "carry"
+						minor_triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "value", "1.255.0.0"),
+				),
+			},
+			{
+				Config: `
+					resource counter_assembly_version this {
+						profile             = "msi"
+						minor_initial_value = 255
+						overflow            = "carry"
+						minor_triggers = {
+							hash = "eggs"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "value", "2.0.0.0"),
+				),
+			},
+			{
+				Config: `
+					resource counter_assembly_version this {
+						profile             = "msi"
+						minor_initial_value = 255
+						overflow            = "carry"
+						max_values = {
+							build = 9
+						}
+						minor_triggers = {
+							hash = "eggs"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "value", "2.0.0.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssemblyVersionResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: assemblyVersionStep("potatoes", "potatoes", "error"),
+			},
+			// Import an existing version
+			{
+				Config:             assemblyVersionStep("potatoes", "potatoes", "error"),
+				ResourceName:       "counter_assembly_version.this",
+				ImportState:        true,
+				ImportStateId:      "2.1.4512.7",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without incrementing
+			{
+				Config: assemblyVersionStep("potatoes", "potatoes", "error"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "value", "2.1.4512.7"),
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "history.#", "1"),
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "history.0.build_triggers.hash", "potatoes"),
+				),
+			},
+			{
+				Config: assemblyVersionStep("eggs", "potatoes", "error"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "value", "2.1.4513.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssemblyVersionResourceUnknownInitialValue(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The initial version isn't known until the source is created
+			{
+				Config: `
+					resource terraform_data source {
+						input = 3
+					}
+
+					resource counter_assembly_version this {
+						major_initial_value = terraform_data.source.output
+						build_triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("counter_assembly_version.this", tfjsonpath.New("value")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_assembly_version.this", "value", "3.0.0.0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,386 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"strconv"
+	"time"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CalendarVersionResource{}
+var _ resource.ResourceWithConfigure = &CalendarVersionResource{}
+var _ resource.ResourceWithModifyPlan = &CalendarVersionResource{}
+var _ resource.ResourceWithImportState = &CalendarVersionResource{}
+var _ resource.ResourceWithConfigValidators = &CalendarVersionResource{}
+var _ resource.ResourceWithValidateConfig = &CalendarVersionResource{}
+
+const defaultCalendarScheme = "YYYY.0M.MICRO"
+
+func NewCalendarVersionResource() resource.Resource {
+	return &CalendarVersionResource{}
+}
+
+type CalendarVersionResource struct {
+	// now returns the current time, as configured by the provider.
+	now func() time.Time
+}
+
+func (c *CalendarVersionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_calendar_version"
+}
+
+func (c *CalendarVersionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A [calendar version](https://calver.org), such as `2024.05.3`, which is produced for the current date when the configured triggers change.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current calendar version.",
+			},
+			"micro_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current value of the `MICRO` or `N` counter, which stays at `micro_initial_value` when the scheme doesn't have one.",
+			},
+			"timestamp": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The RFC 3339 time at which the current version was produced, or null when it was imported.",
+			},
+			"scheme": schema.StringAttribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             stringdefault.StaticString(defaultCalendarScheme),
+				MarkdownDescription: "The [CalVer scheme](https://calver.org/#scheme) of the version, using the `YYYY`, `YY`, `0Y`, `MM`, `0M`, `WW`, `0W`, `DD` and `0D` calendar tokens and a `MICRO` or `N` counter, separated by any other text. The counter resets to `micro_initial_value` whenever a version is produced in a new calendar period. Without a counter, only one version can be produced per period. Weeks are ISO 8601 weeks, and dates are in UTC. Defaults to `YYYY.0M.MICRO`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"micro_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The value of the counter in each new calendar period. Must not be negative. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of versions this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"history": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "A list of calendar versions that this resource has produced.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+						"micro_value": schema.Int64Attribute{
+							Computed: true,
+						},
+						"timestamp": schema.StringAttribute{
+							Computed: true,
+						},
+						"triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause a new version to be produced when any of the values change.",
+			},
+		},
+	}
+}
+
+func (c *CalendarVersionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*counterProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *counterProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	c.now = data.now
+}
+
+func (c *CalendarVersionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		triggersConfigured("triggers"),
+	}
+}
+
+func (c *CalendarVersionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var scheme types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("scheme"), &scheme)...)
+	if resp.Diagnostics.HasError() || scheme.IsNull() || scheme.IsUnknown() {
+		return
+	}
+	if _, err := parseCalendarScheme(scheme.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("scheme"), "Invalid Calendar Scheme", fmt.Sprintf("The scheme is invalid: %s.", err))
+	}
+}
+
+func (c *CalendarVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data calendarVersionModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	// The version depends on the time, so it's only settled here if it couldn't be while planning.
+	if data.Value.IsUnknown() {
+		resp.Diagnostics.Append(c.initialise(ctx, &data)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (c *CalendarVersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (c *CalendarVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	c.lifecycle().update(ctx, req, resp)
+}
+
+func (c *CalendarVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (c *CalendarVersionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	c.lifecycle().modifyPlan(ctx, req, resp)
+}
+
+func (c *CalendarVersionResource) lifecycle() triggeredLifecycle[calendarVersionModelV0] {
+	return triggeredLifecycle[calendarVersionModelV0]{
+		known:      c.inputsKnown,
+		unknown:    c.setUnknownVersion,
+		initialise: c.initialise,
+		advance:    c.advance,
+		// The version depends on the time, so it's only settled while applying if it couldn't be while planning.
+		settled: func(data calendarVersionModelV0) bool { return !data.Value.IsUnknown() },
+	}
+}
+
+func (c *CalendarVersionResource) inputsKnown(data calendarVersionModelV0) bool {
+	return mapFullyKnown(data.Triggers) && !data.Scheme.IsUnknown() && !data.MicroInitialValue.IsUnknown() && !data.MaxHistory.IsUnknown()
+}
+
+func (c *CalendarVersionResource) setUnknownVersion(data *calendarVersionModelV0, creation bool) {
+	data.Value = types.StringUnknown()
+	data.MicroValue = types.Int64Unknown()
+	data.Timestamp = types.StringUnknown()
+	data.History = types.ListUnknown(calendarVersionHistoryEntryType)
+}
+
+// initialise sets the version and history of a calendar version which is being created.
+func (c *CalendarVersionResource) initialise(ctx context.Context, data *calendarVersionModelV0) diag.Diagnostics {
+	var diags diag.Diagnostics
+	scheme, err := parseCalendarScheme(data.Scheme.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("scheme"), "Invalid Calendar Scheme", fmt.Sprintf("The scheme is invalid: %s.", err))
+		return diags
+	}
+
+	now := currentTime(c.now)
+	data.MicroValue = data.MicroInitialValue
+	data.Value = types.StringValue(scheme.render(now, data.MicroValue.ValueInt64()))
+	data.Timestamp = types.StringValue(now.Format(time.RFC3339))
+	data.History = appendAndTruncate(ctx, types.ListNull(calendarVersionHistoryEntryType), c.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return diags
+}
+
+// advance sets the version and history of a calendar version from its prior state, producing a version for the
+// current period when the triggers changed. The counter increments within a period and resets when it rolls over.
+func (c *CalendarVersionResource) advance(ctx context.Context, prior calendarVersionModelV0, data *calendarVersionModelV0, imported bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data.Value = prior.Value
+	data.MicroValue = prior.MicroValue
+	data.Timestamp = prior.Timestamp
+	data.History = prior.History
+
+	if imported {
+		data.History = replaceLastAndTruncate(ctx, prior.History, c.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+		return diags
+	}
+	if prior.Triggers.Equal(data.Triggers) {
+		return diags
+	}
+
+	scheme, err := parseCalendarScheme(data.Scheme.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("scheme"), "Invalid Calendar Scheme", fmt.Sprintf("The scheme is invalid: %s.", err))
+		return diags
+	}
+	currentPeriod, currentMicro, err := scheme.parse(prior.Value.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Resource State", fmt.Sprintf("Unable to parse the current version: %s.", err))
+		return diags
+	}
+
+	now := currentTime(c.now)
+	micro := data.MicroInitialValue.ValueInt64()
+	switch comparePeriods(scheme.period(now), currentPeriod) {
+	case -1:
+		diags.AddAttributeError(
+			path.Root("triggers"),
+			"Version Would Go Backwards",
+			fmt.Sprintf("The current time %s is in an earlier calendar period than the current version %s.", now.Format(time.RFC3339), prior.Value.ValueString()),
+		)
+		return diags
+	case 0:
+		if !scheme.hasMicro() {
+			diags.AddAttributeError(
+				path.Root("triggers"),
+				"Calendar Period Exhausted",
+				fmt.Sprintf("The version %s was already produced in the current calendar period, and the scheme %q has no `MICRO` or `N` counter to produce another.", prior.Value.ValueString(), data.Scheme.ValueString()),
+			)
+			return diags
+		}
+		micro = currentMicro + 1
+	}
+
+	data.MicroValue = types.Int64Value(micro)
+	data.Value = types.StringValue(scheme.render(now, micro))
+	data.Timestamp = types.StringValue(now.Format(time.RFC3339))
+	data.History = appendAndTruncate(ctx, prior.History, c.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return diags
+}
+
+func (c *CalendarVersionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := parseImportId(req.ID, "value", "value", "scheme", "micro_initial_value", "max_history")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: %s. Expected a version such as `2024.05.3`, or `value=24.5.17.2,scheme=YY.MM.DD.N`.", req.ID, err))
+		return
+	}
+
+	data := calendarVersionModelV0{
+		Id:                types.StringValue(uuid.New().String()),
+		Timestamp:         types.StringNull(),
+		Scheme:            types.StringValue(defaultCalendarScheme),
+		MicroInitialValue: types.Int64Value(0),
+		MaxHistory:        types.Int64Value(defaultMaxHistory),
+		Triggers:          types.MapNull(types.StringType),
+	}
+	if scheme, ok := values["scheme"]; ok {
+		data.Scheme = types.StringValue(scheme)
+	}
+	for attribute, raw := range values {
+		if attribute == "value" || attribute == "scheme" {
+			continue
+		}
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Import Identifier", fmt.Sprintf("The value %q for %s is not a whole number.", raw, attribute))
+			continue
+		}
+		switch attribute {
+		case "micro_initial_value":
+			data.MicroInitialValue = types.Int64Value(number)
+		case "max_history":
+			data.MaxHistory = types.Int64Value(number)
+		}
+	}
+
+	scheme, err := parseCalendarScheme(data.Scheme.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("scheme"), "Invalid Import Identifier", fmt.Sprintf("The scheme is invalid: %s.", err))
+		return
+	}
+	if _, micro, err := scheme.parse(values["value"]); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid Import Identifier", fmt.Sprintf("The version %s.", err))
+	} else {
+		data.Value = types.StringValue(values["value"])
+		data.MicroValue = types.Int64Value(micro)
+	}
+	if data.MicroInitialValue.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("micro_initial_value"), "Invalid Import Identifier", "The micro_initial_value must not be negative.")
+	}
+	if data.MaxHistory.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_history"), "Invalid Import Identifier", "The max_history must be at least 1.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.History = appendAndTruncate(ctx, types.ListNull(calendarVersionHistoryEntryType), c.createHistoryEntry(data), data.MaxHistory.ValueInt64())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
+}
+
+var calendarVersionHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":       types.StringType,
+		"micro_value": types.Int64Type,
+		"timestamp":   types.StringType,
+		"triggers":    types.MapType{ElemType: types.StringType},
+	},
+}
+
+func (c *CalendarVersionResource) createHistoryEntry(data calendarVersionModelV0) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		calendarVersionHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"value":       data.Value,
+			"micro_value": data.MicroValue,
+			"timestamp":   data.Timestamp,
+			"triggers":    data.Triggers,
+		},
+	)
+}
+
+type calendarVersionModelV0 struct {
+	Id                types.String `tfsdk:"id"`
+	Value             types.String `tfsdk:"value"`
+	MicroValue        types.Int64  `tfsdk:"micro_value"`
+	Timestamp         types.String `tfsdk:"timestamp"`
+	Scheme            types.String `tfsdk:"scheme"`
+	MicroInitialValue types.Int64  `tfsdk:"micro_initial_value"`
+	MaxHistory        types.Int64  `tfsdk:"max_history"`
+	History           types.List   `tfsdk:"history"`
+	Triggers          types.Map    `tfsdk:"triggers"`
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func calendarVersionStep(fixedTime string, scheme string, hash string) string {
+	return `
+		provider counter {
+			fixed_time = "` + fixedTime + `"
+		}
+
+		resource counter_calendar_version this {
+			scheme = "` + scheme + `"
+			triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccCalendarVersionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: calendarVersionStep("2024-05-17T09:30:00Z", "YYYY.0M.MICRO", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "value", "2024.05.0"),
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "micro_value", "0"),
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "timestamp", "2024-05-17T09:30:00Z"),
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "history.0.value", "2024.05.0"),
+				),
+			},
+			// A change within the same month increments the counter
+			{
+				Config: calendarVersionStep("2024-05-20T12:00:00Z", "YYYY.0M.MICRO", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "value", "2024.05.1"),
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "timestamp", "2024-05-20T12:00:00Z"),
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "history.1.value", "2024.05.1"),
+				),
+			},
+			// Time passing without a change to the triggers keeps the version
+			{
+				Config: calendarVersionStep("2024-06-03T08:00:00Z", "YYYY.0M.MICRO", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "value", "2024.05.1"),
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "history.#", "2"),
+				),
+			},
+			// A change in a new month starts the counter over
+			{
+				Config: calendarVersionStep("2024-06-03T08:00:00Z", "YYYY.0M.MICRO", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "value", "2024.06.0"),
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "micro_value", "0"),
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "history.2.value", "2024.06.0"),
+				),
+			},
+			{
+				Config:      calendarVersionStep("2024-05-31T08:00:00Z", "YYYY.0M.MICRO", "toast"),
+				ExpectError: regexp.MustCompile(`Version Would Go Backwards`),
+			},
+		},
+	})
+}
+
+func TestAccCalendarVersionResourceWithoutCounter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: calendarVersionStep("2024-05-17T09:30:00Z", "YY.0W", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "value", "24.20"),
+				),
+			},
+			{
+				Config:      calendarVersionStep("2024-05-18T09:30:00Z", "YY.0W", "eggs"),
+				ExpectError: regexp.MustCompile(`Calendar Period Exhausted`),
+			},
+			{
+				Config: calendarVersionStep("2024-05-20T09:30:00Z", "YY.0W", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "value", "24.21"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCalendarVersionResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: calendarVersionStep("2024-05-17T09:30:00Z", "YY.MM.DD.N", "potatoes"),
+			},
+			// Import an existing version
+			{
+				Config:             calendarVersionStep("2024-05-17T09:30:00Z", "YY.MM.DD.N", "potatoes"),
+				ResourceName:       "counter_calendar_version.this",
+				ImportState:        true,
+				ImportStateId:      "value=24.5.17.4,scheme=YY.MM.DD.N",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without producing a version
+			{
+				Config: calendarVersionStep("2024-05-17T09:30:00Z", "YY.MM.DD.N", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "value", "24.5.17.4"),
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "history.#", "1"),
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "history.0.triggers.hash", "potatoes"),
+				),
+			},
+			{
+				Config: calendarVersionStep("2024-05-17T11:00:00Z", "YY.MM.DD.N", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_calendar_version.this", "value", "24.5.17.5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCalendarVersionResourceValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      calendarVersionStep("2024-05-17T09:30:00Z", "0M.MICRO", "potatoes"),
+				ExpectError: regexp.MustCompile(`has no year token`),
+			},
+			{
+				Config:      calendarVersionStep("2024-05-17T09:30:00Z", "YYYY.0W.DD", "potatoes"),
+				ExpectError: regexp.MustCompile(`Invalid Calendar Scheme`),
+			},
+			{
+				Config:      calendarVersionStep("yesterday", "YYYY.0M.MICRO", "potatoes"),
+				ExpectError: regexp.MustCompile(`Invalid Fixed Time`),
+			},
+		},
+	})
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// calendarSchemeToken matches the CalVer scheme tokens, see https://calver.org/#scheme. Longer tokens come first so
+// that `YYYY` isn't read as two `YY` tokens. `MICRO` and its alias `N` are the counter within a calendar period.
+var calendarSchemeToken = regexp.MustCompile(`YYYY|0Y|YY|0M|MM|0W|WW|0D|DD|MICRO|N`)
+
+// calendarTokenKinds maps each token to the component it renders.
+var calendarTokenKinds = map[string]string{
+	"YYYY":  "year",
+	"YY":    "year",
+	"0Y":    "year",
+	"MM":    "month",
+	"0M":    "month",
+	"WW":    "week",
+	"0W":    "week",
+	"DD":    "day",
+	"0D":    "day",
+	"MICRO": "micro",
+	"N":     "micro",
+}
+
+// calendarTokenPatterns matches the rendered value of each token.
+var calendarTokenPatterns = map[string]string{
+	"YYYY":  `\d{4}`,
+	"YY":    `[1-9]?\d{1,2}`,
+	"0Y":    `\d{2,3}`,
+	"MM":    `[1-9]\d?`,
+	"0M":    `\d{2}`,
+	"WW":    `[1-9]\d?`,
+	"0W":    `\d{2}`,
+	"DD":    `[1-9]\d?`,
+	"0D":    `\d{2}`,
+	"MICRO": `0|[1-9]\d*`,
+	"N":     `0|[1-9]\d*`,
+}
+
+// calendarScheme is a parsed CalVer scheme such as `YYYY.0M.MICRO`.
+type calendarScheme struct {
+	// parts holds the scheme in order, each being either a token or the literal text between tokens.
+	parts []calendarSchemePart
+	// kinds maps each component in the scheme to its token.
+	kinds   map[string]string
+	pattern *regexp.Regexp
+}
+
+type calendarSchemePart struct {
+	token   string
+	literal string
+}
+
+func parseCalendarScheme(scheme string) (calendarScheme, error) {
+	parsed := calendarScheme{kinds: map[string]string{}}
+	pattern := "^"
+	last := 0
+	for _, match := range calendarSchemeToken.FindAllStringIndex(scheme, -1) {
+		if match[0] > last {
+			parsed.parts = append(parsed.parts, calendarSchemePart{literal: scheme[last:match[0]]})
+			pattern += regexp.QuoteMeta(scheme[last:match[0]])
+		}
+		token := scheme[match[0]:match[1]]
+		kind := calendarTokenKinds[token]
+		if _, ok := parsed.kinds[kind]; ok {
+			return calendarScheme{}, fmt.Errorf("the scheme %q has more than one %s token", scheme, kind)
+		}
+		parsed.kinds[kind] = token
+		parsed.parts = append(parsed.parts, calendarSchemePart{token: token})
+		pattern += "(" + calendarTokenPatterns[token] + ")"
+		last = match[1]
+	}
+	if last < len(scheme) {
+		parsed.parts = append(parsed.parts, calendarSchemePart{literal: scheme[last:]})
+		pattern += regexp.QuoteMeta(scheme[last:])
+	}
+
+	_, month := parsed.kinds["month"]
+	_, week := parsed.kinds["week"]
+	_, day := parsed.kinds["day"]
+	switch {
+	case parsed.kinds["year"] == "":
+		return calendarScheme{}, fmt.Errorf("the scheme %q has no year token", scheme)
+	case month && week:
+		return calendarScheme{}, fmt.Errorf("the scheme %q can't have both month and week tokens", scheme)
+	case day && !month:
+		return calendarScheme{}, fmt.Errorf("the scheme %q has a day token without a month token", scheme)
+	}
+	parsed.pattern = regexp.MustCompile(pattern + "$")
+	return parsed, nil
+}
+
+// hasMicro reports whether the scheme has a counter, without which only one version can be produced per period.
+func (s calendarScheme) hasMicro() bool {
+	_, ok := s.kinds["micro"]
+	return ok
+}
+
+// period returns the calendar components of a time present in the scheme, from the most significant. Schemes with a
+// week use the ISO 8601 week-numbering year, so the last days of December can belong to week 1 of the next year.
+func (s calendarScheme) period(t time.Time) []int64 {
+	year, week := t.ISOWeek()
+	if _, ok := s.kinds["week"]; !ok {
+		year = t.Year()
+	}
+	components := map[string]int64{
+		"year":  int64(year),
+		"month": int64(t.Month()),
+		"week":  int64(week),
+		"day":   int64(t.Day()),
+	}
+
+	var period []int64
+	for _, kind := range []string{"year", "month", "week", "day"} {
+		if _, ok := s.kinds[kind]; ok {
+			period = append(period, components[kind])
+		}
+	}
+	return period
+}
+
+// render formats a version for the period containing the time.
+func (s calendarScheme) render(t time.Time, micro int64) string {
+	period := s.period(t)
+	values := map[string]int64{"micro": micro}
+	i := 0
+	for _, kind := range []string{"year", "month", "week", "day"} {
+		if _, ok := s.kinds[kind]; ok {
+			values[kind] = period[i]
+			i++
+		}
+	}
+
+	var rendered strings.Builder
+	for _, part := range s.parts {
+		if part.token == "" {
+			rendered.WriteString(part.literal)
+			continue
+		}
+		value := values[calendarTokenKinds[part.token]]
+		switch part.token {
+		case "YY":
+			rendered.WriteString(strconv.FormatInt(value-2000, 10))
+		case "0Y":
+			rendered.WriteString(fmt.Sprintf("%02d", value-2000))
+		case "0M", "0W", "0D":
+			rendered.WriteString(fmt.Sprintf("%02d", value))
+		default:
+			rendered.WriteString(strconv.FormatInt(value, 10))
+		}
+	}
+	return rendered.String()
+}
+
+// parse returns the calendar period and counter of a version rendered with the scheme.
+func (s calendarScheme) parse(value string) ([]int64, int64, error) {
+	matches := s.pattern.FindStringSubmatch(value)
+	if matches == nil {
+		return nil, 0, fmt.Errorf("%q doesn't match the scheme", value)
+	}
+
+	values := map[string]int64{}
+	group := 1
+	for _, part := range s.parts {
+		if part.token == "" {
+			continue
+		}
+		number, err := strconv.ParseInt(matches[group], 10, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%q doesn't match the scheme: %w", value, err)
+		}
+		if part.token == "YY" || part.token == "0Y" {
+			number += 2000
+		}
+		values[calendarTokenKinds[part.token]] = number
+		group++
+	}
+
+	var period []int64
+	for _, kind := range []string{"year", "month", "week", "day"} {
+		if _, ok := s.kinds[kind]; ok {
+			period = append(period, values[kind])
+		}
+	}
+	return period, values["micro"], nil
+}
+
+// comparePeriods compares periods returned by calendarScheme.period, returning -1, 0 or 1.
+func comparePeriods(a []int64, b []int64) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return 0
+}
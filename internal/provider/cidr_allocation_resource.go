@@ -0,0 +1,407 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"maps"
+	"math"
+	"math/big"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CIDRAllocationResource{}
+var _ resource.ResourceWithModifyPlan = &CIDRAllocationResource{}
+var _ resource.ResourceWithImportState = &CIDRAllocationResource{}
+var _ resource.ResourceWithValidateConfig = &CIDRAllocationResource{}
+
+func NewCIDRAllocationResource() resource.Resource {
+	return &CIDRAllocationResource{}
+}
+
+type CIDRAllocationResource struct {
+}
+
+func (c CIDRAllocationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cidr_allocation"
+}
+
+func (c CIDRAllocationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Splits a base CIDR into subnets of the same size and allocates one to each of a set of keys. New keys receive the lowest free subnet, and existing keys keep theirs when others are added or removed, like a stable index for `cidrsubnet`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"base_cidr": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The IPv4 or IPv6 address space to split, such as `10.0.0.0/16`. Changing it moves every subnet to the same index within the new address space.",
+			},
+			"prefix_length": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The prefix length of each subnet, such as `24`. It must be at least the prefix length of `base_cidr` and at most 32 for IPv4 or 128 for IPv6.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 128),
+				},
+			},
+			"keys": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "The keys which need a subnet. To allocate a subnet to each entry of a map, pass its keys, such as `keys(var.teams)`. Removing a key frees its subnet for the next new key.",
+			},
+			"indexes": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "The index of each key's subnet within `base_cidr`, as used by `cidrsubnet`.",
+			},
+			"cidrs": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "The subnet allocated to each key, such as `10.0.3.0/24`.",
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of events this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"history": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "A list of the allocations and releases that this resource has made. Each entry has the `key`, its `index` and `cidr`, and an `action` of `allocated` or `released`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed: true,
+						},
+						"index": schema.Int64Attribute{
+							Computed: true,
+						},
+						"cidr": schema.StringAttribute{
+							Computed: true,
+						},
+						"action": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (c CIDRAllocationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data cidrAllocationModelV0
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.BaseCIDR.IsNull() || data.BaseCIDR.IsUnknown() {
+		return
+	}
+
+	base, err := parseBaseCIDR(data.BaseCIDR.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("base_cidr"), "Invalid Base CIDR", fmt.Sprintf("The base CIDR %s.", err))
+		return
+	}
+	if data.PrefixLength.IsNull() || data.PrefixLength.IsUnknown() {
+		return
+	}
+	if err := checkPrefixLength(base, data.PrefixLength.ValueInt64()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("prefix_length"), "Invalid Prefix Length", fmt.Sprintf("The prefix length %s.", err))
+	}
+}
+
+func (c CIDRAllocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data cidrAllocationModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	// The allocations are only settled here if they couldn't be while planning.
+	if data.Indexes.IsUnknown() {
+		data.History = types.ListNull(cidrAllocationHistoryEntryType)
+		resp.Diagnostics.Append(c.allocate(ctx, types.MapValueMust(types.Int64Type, nil), types.MapValueMust(types.StringType, nil), &data)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (c CIDRAllocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (c CIDRAllocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data cidrAllocationModelV0
+	var prior cidrAllocationModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The allocations are only settled here if they couldn't be while planning.
+	if data.Indexes.IsUnknown() {
+		data.History = prior.History
+		resp.Diagnostics.Append(c.allocate(ctx, prior.Indexes, prior.CIDRs, &data)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (c CIDRAllocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (c CIDRAllocationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data cidrAllocationModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Which subnets are allocated can't be known until the keys and address space are, for example when they are
+	// derived from a resource which is being replaced. Create and Update settle the allocations once they are known.
+	if !setFullyKnown(data.Keys) || data.BaseCIDR.IsUnknown() || data.PrefixLength.IsUnknown() || data.MaxHistory.IsUnknown() {
+		data.Indexes = types.MapUnknown(types.Int64Type)
+		data.CIDRs = types.MapUnknown(types.StringType)
+		data.History = types.ListUnknown(cidrAllocationHistoryEntryType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+		return
+	}
+
+	indexes, cidrs := types.MapValueMust(types.Int64Type, nil), types.MapValueMust(types.StringType, nil)
+	data.History = types.ListNull(cidrAllocationHistoryEntryType)
+	if !req.State.Raw.IsNull() {
+		var prior cidrAllocationModelV0
+		resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+		indexes, cidrs = prior.Indexes, prior.CIDRs
+		data.History = prior.History
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(c.allocate(ctx, indexes, cidrs, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+// allocate releases the subnets of keys which were removed and allocates the lowest free subnet to keys which were
+// added, in key order, recording each in the history which data holds from the prior state.
+func (c CIDRAllocationResource) allocate(ctx context.Context, priorIndexes types.Map, priorCIDRs types.Map, data *cidrAllocationModelV0) diag.Diagnostics {
+	var diags diag.Diagnostics
+	base, err := parseBaseCIDR(data.BaseCIDR.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("base_cidr"), "Invalid Base CIDR", fmt.Sprintf("The base CIDR %s.", err))
+		return diags
+	}
+	if err := checkPrefixLength(base, data.PrefixLength.ValueInt64()); err != nil {
+		diags.AddAttributeError(path.Root("prefix_length"), "Invalid Prefix Length", fmt.Sprintf("The prefix length %s.", err))
+		return diags
+	}
+	prefixLength := int(data.PrefixLength.ValueInt64())
+	var keys []string
+	diags.Append(data.Keys.ElementsAs(ctx, &keys, false)...)
+	indexes := map[string]int64{}
+	diags.Append(priorIndexes.ElementsAs(ctx, &indexes, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	slices.Sort(keys)
+	maximum := int64(math.MaxInt64)
+	if newBits := prefixLength - base.Bits(); newBits < 63 {
+		maximum = 1<<newBits - 1
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(indexes)) {
+		if slices.Contains(keys, key) {
+			continue
+		}
+		cidr, _ := priorCIDRs.Elements()[key].(types.String)
+		if cidr.IsNull() {
+			cidr = types.StringValue(cidrSubnet(base, prefixLength, indexes[key]).String())
+		}
+		data.History = appendAndTruncate(ctx, data.History, c.createHistoryEntry(key, indexes[key], cidr, "released"), data.MaxHistory.ValueInt64())
+		delete(indexes, key)
+	}
+
+	used := map[int64]bool{}
+	for _, key := range slices.Sorted(maps.Keys(indexes)) {
+		index := indexes[key]
+		used[index] = true
+		if index > maximum {
+			diags.AddAttributeError(
+				path.Root("prefix_length"),
+				"Subnet Outside Address Space",
+				fmt.Sprintf("The key %q has the subnet index %d, which doesn't fit in %s with a prefix length of %d, and existing keys are never renumbered.", key, index, base, prefixLength),
+			)
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+	for _, key := range keys {
+		if _, ok := indexes[key]; ok {
+			continue
+		}
+		index, ok := lowestFreeAllocation(key, 0, maximum, nil, used, nil, nil)
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("keys"),
+				"Address Space Exhausted",
+				fmt.Sprintf("All %d subnets of %s with a prefix length of %d are allocated, so there is none for the key %q.", maximum+1, base, prefixLength, key),
+			)
+			return diags
+		}
+		indexes[key] = index
+		used[index] = true
+		data.History = appendAndTruncate(ctx, data.History, c.createHistoryEntry(key, index, types.StringValue(cidrSubnet(base, prefixLength, index).String()), "allocated"), data.MaxHistory.ValueInt64())
+	}
+
+	indexValues, cidrValues := map[string]attr.Value{}, map[string]attr.Value{}
+	for key, index := range indexes {
+		indexValues[key] = types.Int64Value(index)
+		cidrValues[key] = types.StringValue(cidrSubnet(base, prefixLength, index).String())
+	}
+	data.Indexes = types.MapValueMust(types.Int64Type, indexValues)
+	data.CIDRs = types.MapValueMust(types.StringType, cidrValues)
+	return diags
+}
+
+// parseBaseCIDR parses an IPv4 or IPv6 CIDR, which must not have any bits set after the prefix.
+func parseBaseCIDR(value string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return prefix, fmt.Errorf("%q is not a CIDR such as `10.0.0.0/16`", value)
+	}
+	if prefix != prefix.Masked() {
+		return prefix, fmt.Errorf("%q has bits set after the prefix, use %q instead", value, prefix.Masked().String())
+	}
+	return prefix, nil
+}
+
+// checkPrefixLength checks that subnets with the prefix length fit within base.
+func checkPrefixLength(base netip.Prefix, length int64) error {
+	if length < int64(base.Bits()) || length > int64(base.Addr().BitLen()) {
+		return fmt.Errorf("%d must be between %d and %d to split %s", length, base.Bits(), base.Addr().BitLen(), base)
+	}
+	return nil
+}
+
+// cidrSubnet returns the subnet with the given index and prefix length within base, like Terraform's `cidrsubnet`.
+func cidrSubnet(base netip.Prefix, prefixLength int, index int64) netip.Prefix {
+	address := base.Addr().AsSlice()
+	offset := new(big.Int).Lsh(big.NewInt(index), uint(base.Addr().BitLen()-prefixLength))
+	sum := new(big.Int).Add(new(big.Int).SetBytes(address), offset)
+	subnet, _ := netip.AddrFromSlice(sum.FillBytes(make([]byte, len(address))))
+	return netip.PrefixFrom(subnet, prefixLength)
+}
+
+func (c CIDRAllocationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	data := cidrAllocationModelV0{
+		Id:           types.StringValue(uuid.New().String()),
+		BaseCIDR:     types.StringNull(),
+		PrefixLength: types.Int64Null(),
+		CIDRs:        types.MapNull(types.StringType),
+		MaxHistory:   types.Int64Value(defaultMaxHistory),
+		History:      types.ListNull(cidrAllocationHistoryEntryType),
+	}
+
+	// The identifier lists the existing subnet index of each key, such as `payments=0,search=3`. The base CIDR and
+	// prefix length are taken from the configuration.
+	keys := []attr.Value{}
+	indexes := map[string]attr.Value{}
+	for _, pair := range strings.Split(req.ID, ",") {
+		key, raw, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		index, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if !found || key == "" || err != nil || index < 0 {
+			resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: expected `key=index`, got %q. Expected subnet indexes such as `payments=0,search=3`.", req.ID, pair))
+			return
+		}
+		if _, duplicate := indexes[key]; duplicate {
+			resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: the key %q is specified more than once.", req.ID, key))
+			return
+		}
+		for other, allocated := range indexes {
+			if allocated.(types.Int64).ValueInt64() == index {
+				resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: the keys %q and %q both have the subnet index %d.", req.ID, other, key, index))
+				return
+			}
+		}
+		keys = append(keys, types.StringValue(key))
+		indexes[key] = types.Int64Value(index)
+		data.History = appendAndTruncate(ctx, data.History, c.createHistoryEntry(key, index, types.StringNull(), "allocated"), data.MaxHistory.ValueInt64())
+	}
+	data.Keys = types.SetValueMust(types.StringType, keys)
+	data.Indexes = types.MapValueMust(types.Int64Type, indexes)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+var cidrAllocationHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"key":    types.StringType,
+		"index":  types.Int64Type,
+		"cidr":   types.StringType,
+		"action": types.StringType,
+	},
+}
+
+func (c CIDRAllocationResource) createHistoryEntry(key string, index int64, cidr types.String, action string) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		cidrAllocationHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"key":    types.StringValue(key),
+			"index":  types.Int64Value(index),
+			"cidr":   cidr,
+			"action": types.StringValue(action),
+		},
+	)
+}
+
+type cidrAllocationModelV0 struct {
+	Id           types.String `tfsdk:"id"`
+	BaseCIDR     types.String `tfsdk:"base_cidr"`
+	PrefixLength types.Int64  `tfsdk:"prefix_length"`
+	Keys         types.Set    `tfsdk:"keys"`
+	Indexes      types.Map    `tfsdk:"indexes"`
+	CIDRs        types.Map    `tfsdk:"cidrs"`
+	MaxHistory   types.Int64  `tfsdk:"max_history"`
+	History      types.List   `tfsdk:"history"`
+}
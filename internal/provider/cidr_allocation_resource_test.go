@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func cidrAllocationStep(prefixLength string, keys string) string {
+	return `
+		resource counter_cidr_allocation this {
+			base_cidr     = "10.0.0.0/22"
+			prefix_length = ` + prefixLength + `
+			keys          = [` + keys + `]
+		}
+	`
+}
+
+func TestAccCIDRAllocationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cidrAllocationStep("24", `"payments", "search"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "cidrs.payments", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "cidrs.search", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "indexes.search", "1"),
+				),
+			},
+			// Removing a key frees its subnet for the next new key without moving the others
+			{
+				Config: cidrAllocationStep("24", `"search", "web", "ml"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "cidrs.ml", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "cidrs.search", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "cidrs.web", "10.0.2.0/24"),
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "history.2.key", "payments"),
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "history.2.action", "released"),
+				),
+			},
+			{
+				Config:      cidrAllocationStep("24", `"search", "web", "ml", "data", "ops"`),
+				ExpectError: regexp.MustCompile(`All 4 subnets of 10.0.0.0/22 with a prefix length of 24 are allocated`),
+			},
+			// Larger subnets can't hold the existing indexes
+			{
+				Config:      cidrAllocationStep("23", `"search", "web", "ml"`),
+				ExpectError: regexp.MustCompile(`Subnet Outside Address Space`),
+			},
+		},
+	})
+}
+
+func TestAccCIDRAllocationResourceValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource counter_cidr_allocation this {
+						base_cidr     = "10.0.0.1/16"
+						prefix_length = 24
+						keys          = ["payments"]
+					}
+				`,
+				ExpectError: regexp.MustCompile(`use "10.0.0.0/16" instead`),
+			},
+			{
+				Config:      cidrAllocationStep("20", `"payments"`),
+				ExpectError: regexp.MustCompile(`must be between 22 and 32`),
+			},
+		},
+	})
+}
+
+func TestAccCIDRAllocationResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cidrAllocationStep("24", `"payments", "search"`),
+			},
+			{
+				Config:             cidrAllocationStep("24", `"payments", "search"`),
+				ResourceName:       "counter_cidr_allocation.this",
+				ImportState:        true,
+				ImportStateId:      "payments=2,search=0",
+				ImportStatePersist: true,
+			},
+			{
+				Config: cidrAllocationStep("24", `"payments", "search", "web"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "cidrs.payments", "10.0.2.0/24"),
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "cidrs.search", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr("counter_cidr_allocation.this", "cidrs.web", "10.0.1.0/24"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"time"
+)
+
+// dnsSerialModulus is the size of the SOA serial number space, which is an unsigned 32 bit integer.
+const dnsSerialModulus = int64(1) << 32
+
+// dnsSerialMaxIncrement is the largest increment which secondaries recognise as an increase under RFC 1982.
+const dnsSerialMaxIncrement = int64(1)<<31 - 1
+
+// dnsSerialBase returns the first `YYYYMMDDnn` serial of the day recommended by RFC 1912.
+func dnsSerialBase(t time.Time) int64 {
+	year, month, day := t.Date()
+	return (int64(year)*1000000 + int64(month)*10000 + int64(day)*100) % dnsSerialModulus
+}
+
+// dnsSerialGreater reports whether serial a is greater than serial b under RFC 1982 serial number arithmetic, where the
+// comparison is undefined, and so false, for serials exactly half the serial space apart.
+func dnsSerialGreater(a int64, b int64) bool {
+	distance := (a - b + dnsSerialModulus) % dnsSerialModulus
+	return distance > 0 && distance <= dnsSerialMaxIncrement
+}
+
+// nextDNSSerial returns the serial following prior at the given time. It moves to the day's base when that is greater,
+// and otherwise increments, so more than 100 changes in a day carry into the date part. It also reports whether the
+// serial wrapped around the serial space, which secondaries only follow if they hold a serial less than half the
+// serial space behind.
+func nextDNSSerial(prior int64, now time.Time) (int64, bool) {
+	if base := dnsSerialBase(now); dnsSerialGreater(base, prior) {
+		return base, base < prior
+	}
+	next := (prior + 1) % dnsSerialModulus
+	return next, next < prior
+}
@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"strconv"
+	"time"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DNSSerialResource{}
+var _ resource.ResourceWithConfigure = &DNSSerialResource{}
+var _ resource.ResourceWithModifyPlan = &DNSSerialResource{}
+var _ resource.ResourceWithImportState = &DNSSerialResource{}
+var _ resource.ResourceWithConfigValidators = &DNSSerialResource{}
+
+func NewDNSSerialResource() resource.Resource {
+	return &DNSSerialResource{}
+}
+
+type DNSSerialResource struct {
+	// now returns the current time, as configured by the provider.
+	now func() time.Time
+}
+
+func (d *DNSSerialResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_serial"
+}
+
+func (d *DNSSerialResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A DNS zone SOA serial number in the `YYYYMMDDnn` form recommended by [RFC 1912](https://www.rfc-editor.org/rfc/rfc1912#section-2.2), which increases when the configured triggers change.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current serial. Each change moves to the current date in UTC with `nn` at `00`, or increments the serial when that wouldn't be an increase, so more than 100 changes in a day carry into the date part. Serials are compared with [RFC 1982](https://www.rfc-editor.org/rfc/rfc1982) serial number arithmetic and wrap around after 4294967295.",
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of serials this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"history": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "A list of serials that this resource has produced.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.Int64Attribute{
+							Computed: true,
+						},
+						"triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the serial to increase when any of the values change.",
+			},
+		},
+	}
+}
+
+func (d *DNSSerialResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*counterProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *counterProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.now = data.now
+}
+
+func (d *DNSSerialResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		triggersConfigured("triggers"),
+	}
+}
+
+func (d *DNSSerialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data dnsSerialModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	// The serial depends on the date, so it's only settled here if it couldn't be while planning.
+	if data.Value.IsUnknown() {
+		resp.Diagnostics.Append(d.initialise(ctx, &data)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *DNSSerialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (d *DNSSerialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	d.lifecycle().update(ctx, req, resp)
+}
+
+func (d *DNSSerialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (d *DNSSerialResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	d.lifecycle().modifyPlan(ctx, req, resp)
+}
+
+func (d *DNSSerialResource) lifecycle() triggeredLifecycle[dnsSerialModelV0] {
+	return triggeredLifecycle[dnsSerialModelV0]{
+		known:      d.inputsKnown,
+		unknown:    d.setUnknownSerial,
+		initialise: d.initialise,
+		advance:    d.advance,
+		// The serial depends on the date, so it's only settled while applying if it couldn't be while planning.
+		settled: func(data dnsSerialModelV0) bool { return !data.Value.IsUnknown() },
+	}
+}
+
+func (d *DNSSerialResource) inputsKnown(data dnsSerialModelV0) bool {
+	return mapFullyKnown(data.Triggers) && !data.MaxHistory.IsUnknown()
+}
+
+func (d *DNSSerialResource) setUnknownSerial(data *dnsSerialModelV0, creation bool) {
+	data.Value = types.Int64Unknown()
+	data.History = types.ListUnknown(dnsSerialHistoryEntryType)
+}
+
+// initialise sets the serial and history of a serial which is being created to the base of the current date.
+func (d *DNSSerialResource) initialise(ctx context.Context, data *dnsSerialModelV0) diag.Diagnostics {
+	data.Value = types.Int64Value(dnsSerialBase(currentTime(d.now)))
+	data.History = appendAndTruncate(ctx, types.ListNull(dnsSerialHistoryEntryType), d.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return nil
+}
+
+// advance sets the serial and history from the prior state, producing the next serial when the triggers changed. A
+// warning is returned when the serial wraps around, as secondaries more than half the serial space behind won't
+// recognise the new serial as an increase.
+func (d *DNSSerialResource) advance(ctx context.Context, prior dnsSerialModelV0, data *dnsSerialModelV0, imported bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data.Value = prior.Value
+	data.History = prior.History
+
+	if imported {
+		data.History = replaceLastAndTruncate(ctx, prior.History, d.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+		return diags
+	}
+	if prior.Triggers.Equal(data.Triggers) {
+		return diags
+	}
+
+	next, wrapped := nextDNSSerial(prior.Value.ValueInt64(), currentTime(d.now))
+	if wrapped {
+		diags.AddAttributeWarning(
+			path.Root("value"),
+			"DNS Serial Wrapped",
+			fmt.Sprintf("The serial wraps around from %d to %d. Secondaries recognise this as an increase only if they hold a serial less than 2147483647 behind, so they should be checked before applying.", prior.Value.ValueInt64(), next),
+		)
+	}
+	data.Value = types.Int64Value(next)
+	data.History = appendAndTruncate(ctx, prior.History, d.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return diags
+}
+
+func (d *DNSSerialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := parseImportId(req.ID, "value", "value", "max_history")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: %s. Expected a serial such as `2024051703`, or `value=2024051703,max_history=10`.", req.ID, err))
+		return
+	}
+
+	data := dnsSerialModelV0{
+		Id:         types.StringValue(uuid.New().String()),
+		MaxHistory: types.Int64Value(defaultMaxHistory),
+		Triggers:   types.MapNull(types.StringType),
+	}
+	for attribute, raw := range values {
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Import Identifier", fmt.Sprintf("The value %q for %s is not a whole number.", raw, attribute))
+			continue
+		}
+		switch attribute {
+		case "value":
+			data.Value = types.Int64Value(number)
+		case "max_history":
+			data.MaxHistory = types.Int64Value(number)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if value := data.Value.ValueInt64(); value < 0 || value >= dnsSerialModulus {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid Import Identifier", fmt.Sprintf("The serial %d is not an unsigned 32 bit integer.", value))
+	}
+	if data.MaxHistory.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_history"), "Invalid Import Identifier", "The max_history must be at least 1.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.History = appendAndTruncate(ctx, types.ListNull(dnsSerialHistoryEntryType), d.createHistoryEntry(data), data.MaxHistory.ValueInt64())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
+}
+
+var dnsSerialHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":    types.Int64Type,
+		"triggers": types.MapType{ElemType: types.StringType},
+	},
+}
+
+func (d *DNSSerialResource) createHistoryEntry(data dnsSerialModelV0) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		dnsSerialHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"value":    data.Value,
+			"triggers": data.Triggers,
+		},
+	)
+}
+
+type dnsSerialModelV0 struct {
+	Id         types.String `tfsdk:"id"`
+	Value      types.Int64  `tfsdk:"value"`
+	MaxHistory types.Int64  `tfsdk:"max_history"`
+	History    types.List   `tfsdk:"history"`
+	Triggers   types.Map    `tfsdk:"triggers"`
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func dnsSerialStep(fixedTime string, hash string) string {
+	return `
+		provider counter {
+			fixed_time = "` + fixedTime + `"
+		}
+
+		resource counter_dns_serial this {
+			triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccDNSSerialResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: dnsSerialStep("2024-05-17T09:30:00Z", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "value", "2024051700"),
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "history.0.value", "2024051700"),
+				),
+			},
+			// A change on the same day increments the serial
+			{
+				Config: dnsSerialStep("2024-05-17T15:00:00Z", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "value", "2024051701"),
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "history.1.value", "2024051701"),
+				),
+			},
+			// A change on a later day moves to that day's base
+			{
+				Config: dnsSerialStep("2024-05-20T08:00:00Z", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "value", "2024052000"),
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "history.2.value", "2024052000"),
+				),
+			},
+			// A clock running behind never decreases the serial
+			{
+				Config: dnsSerialStep("2024-05-19T08:00:00Z", "toast"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "value", "2024052001"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSSerialResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: dnsSerialStep("2024-05-17T09:30:00Z", "potatoes"),
+			},
+			// Import a serial which has used up the day
+			{
+				Config:             dnsSerialStep("2024-05-17T09:30:00Z", "potatoes"),
+				ResourceName:       "counter_dns_serial.this",
+				ImportState:        true,
+				ImportStateId:      "2024051799",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without incrementing
+			{
+				Config: dnsSerialStep("2024-05-17T09:30:00Z", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "value", "2024051799"),
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "history.#", "1"),
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "history.0.triggers.hash", "potatoes"),
+				),
+			},
+			// More than 100 changes in a day carry into the date part
+			{
+				Config: dnsSerialStep("2024-05-17T10:00:00Z", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "value", "2024051800"),
+				),
+			},
+			{
+				Config: dnsSerialStep("2024-05-18T10:00:00Z", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "value", "2024051801"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSSerialResourceWraparound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: dnsSerialStep("2024-05-17T09:30:00Z", "potatoes"),
+			},
+			{
+				Config:             dnsSerialStep("2024-05-17T09:30:00Z", "potatoes"),
+				ResourceName:       "counter_dns_serial.this",
+				ImportState:        true,
+				ImportStateId:      "4294967295",
+				ImportStatePersist: true,
+			},
+			{
+				Config: dnsSerialStep("2024-05-17T09:30:00Z", "potatoes"),
+			},
+			// Under serial number arithmetic the date base follows the largest serial
+			{
+				Config: dnsSerialStep("2024-05-17T09:30:00Z", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_dns_serial.this", "value", "2024051700"),
+				),
+			},
+			{
+				Config:        dnsSerialStep("2024-05-17T09:30:00Z", "eggs"),
+				ResourceName:  "counter_dns_serial.this",
+				ImportState:   true,
+				ImportStateId: "4294967296",
+				ExpectError:   regexp.MustCompile(`not an unsigned 32 bit integer`),
+			},
+		},
+	})
+}
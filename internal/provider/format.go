@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"slices"
+)
+
+// counterRadixes are the digit alphabets which counter values can be formatted in, keyed by name.
+var counterRadixes = map[string]string{
+	"decimal":   "0123456789",
+	"hex":       "0123456789abcdef",
+	"base36":    "0123456789abcdefghijklmnopqrstuvwxyz",
+	"crockford": "0123456789ABCDEFGHJKMNPQRSTVWXYZ",
+}
+
+// counterRadixNames lists the names of counterRadixes in the order they're documented.
+var counterRadixNames = []string{"decimal", "hex", "base36", "crockford"}
+
+// formatCounter renders a counter value in the named radix, padded with leading zeros to at least width digits and
+// preceded by prefix. Padding keeps the lexical order of non-negative values the same as their numeric order.
+func formatCounter(value int64, width int64, radix string, prefix string) string {
+	alphabet, ok := counterRadixes[radix]
+	if !ok {
+		alphabet = counterRadixes["decimal"]
+	}
+	base := uint64(len(alphabet))
+
+	sign := ""
+	magnitude := uint64(value)
+	if value < 0 {
+		sign = "-"
+		magnitude = -magnitude
+	}
+
+	var digits []byte
+	for {
+		digits = append(digits, alphabet[magnitude%base])
+		magnitude /= base
+		if magnitude == 0 {
+			break
+		}
+	}
+	for int64(len(digits)) < width {
+		digits = append(digits, alphabet[0])
+	}
+	slices.Reverse(digits)
+	return prefix + sign + string(digits)
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &FormatCounterFunction{}
+
+func NewFormatCounterFunction() function.Function {
+	return &FormatCounterFunction{}
+}
+
+type FormatCounterFunction struct {
+}
+
+func (f FormatCounterFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format_counter"
+}
+
+func (f FormatCounterFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Format a counter value",
+		MarkdownDescription: "Renders a counter value in the same way as the `formatted_value` of `counter_monotonic`, for example as `build-000412` or in base36.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "value",
+				MarkdownDescription: "The value to format.",
+			},
+		},
+		VariadicParameter: function.MapParameter{
+			Name:                "options",
+			ElementType:         types.StringType,
+			MarkdownDescription: "An optional map of `width`, the minimum number of digits, which are padded with leading zeros; `radix`, one of `decimal`, `hex`, `base36` or `crockford`; and `prefix`.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f FormatCounterFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value int64
+	var options []map[string]string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value, &options))
+	if resp.Error != nil {
+		return
+	}
+	if len(options) > 1 {
+		resp.Error = function.NewArgumentFuncError(2, "only one options map may be given")
+		return
+	}
+
+	var width int64
+	radix := "decimal"
+	var prefix string
+	if len(options) == 1 {
+		for key, option := range options[0] {
+			switch key {
+			case "width":
+				var err error
+				if width, err = strconv.ParseInt(option, 10, 64); err != nil || width < 0 || width > 64 {
+					resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("the width %q must be a whole number between 0 and 64", option))
+					return
+				}
+			case "radix":
+				if !slices.Contains(counterRadixNames, option) {
+					resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("the radix %q must be one of: %s", option, strings.Join(counterRadixNames, ", ")))
+					return
+				}
+				radix = option
+			case "prefix":
+				prefix = option
+			default:
+				resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unsupported option %q, expected one of: width, radix, prefix", key))
+				return
+			}
+		}
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, formatCounter(value, width, radix, prefix)))
+}
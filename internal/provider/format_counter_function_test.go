@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFormatCounterFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test formats
+			{
+				Config: `
+					output "default" {
+						value = provider::counter::format_counter(412)
+					}
+					output "padded" {
+						value = provider::counter::format_counter(412, { width = 6, prefix = "build-" })
+					}
+					output "base36" {
+						value = provider::counter::format_counter(412, { radix = "base36" })
+					}
+					output "crockford" {
+						value = provider::counter::format_counter(412, { radix = "crockford", width = 4 })
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("default", "412"),
+					resource.TestCheckOutput("padded", "build-000412"),
+					resource.TestCheckOutput("base36", "bg"),
+					resource.TestCheckOutput("crockford", "00CW"),
+				),
+			},
+			// Test unsupported radix
+			{
+				Config: `
+					output "invalid" {
+						value = provider::counter::format_counter(412, { radix = "octal" })
+					}
+				`,
+				ExpectError: regexp.MustCompile(`must be one of: decimal, hex, base36, crockford`),
+			},
+		},
+	})
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"strconv"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MonotonicMapResource{}
+var _ resource.ResourceWithModifyPlan = &MonotonicMapResource{}
+var _ resource.ResourceWithImportState = &MonotonicMapResource{}
+
+func NewMonotonicMapResource() resource.Resource {
+	return &MonotonicMapResource{}
+}
+
+type MonotonicMapResource struct {
+}
+
+func (m MonotonicMapResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monotonic_map"
+}
+
+func (m MonotonicMapResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A monotonic counter for each key of `triggers`, which increments when the triggers of its key change. Keys can be added or removed without disturbing the others, so one resource can replace many `counter_monotonic` resources created with `for_each`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"values": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "The current value of the counter for each key.",
+			},
+			"counters": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current value and the history of the counter for each key.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.Int64Attribute{
+							Computed: true,
+						},
+						"history": schema.ListNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"value": schema.Int64Attribute{
+										Computed: true,
+									},
+									"triggers": schema.MapAttribute{
+										ElementType: types.StringType,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.MapType{ElemType: types.StringType},
+				Required:            true,
+				MarkdownDescription: "A map of keys to a map of strings that will cause a change to the counter of that key when any of the values change.",
+			},
+			"initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The initial value of the counter for each new key. Changing it doesn't affect existing keys.",
+			},
+			"step": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "The amount used to increment / decrement the counters on each revision. Must not be zero.",
+				Validators: []validator.Int64{
+					int64validator.NoneOf(0),
+				},
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of values to store in the history of each counter. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (m MonotonicMapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data monotonicMapModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	resp.Diagnostics.Append(m.initialise(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m MonotonicMapResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (m MonotonicMapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	m.lifecycle().update(ctx, req, resp)
+}
+
+func (m MonotonicMapResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (m MonotonicMapResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	m.lifecycle().modifyPlan(ctx, req, resp)
+}
+
+func (m MonotonicMapResource) lifecycle() triggeredLifecycle[monotonicMapModelV0] {
+	return triggeredLifecycle[monotonicMapModelV0]{
+		known:      m.inputsKnown,
+		unknown:    m.setUnknownCounters,
+		initialise: m.initialise,
+		advance:    m.advance,
+	}
+}
+
+// inputsKnown reports whether the keys and the settings shared by every counter are known. The triggers of a single
+// key may still be unknown, which advance handles for that key alone.
+func (m MonotonicMapResource) inputsKnown(data monotonicMapModelV0) bool {
+	return !data.Triggers.IsUnknown() && !data.InitialValue.IsUnknown() && !data.Step.IsUnknown() && !data.MaxHistory.IsUnknown()
+}
+
+func (m MonotonicMapResource) setUnknownCounters(data *monotonicMapModelV0, creation bool) {
+	data.Values = types.MapUnknown(types.Int64Type)
+	data.Counters = types.MapUnknown(monotonicMapCounterType)
+}
+
+// initialise sets the counters of a resource which is being created, each of which starts at the initial value.
+func (m MonotonicMapResource) initialise(ctx context.Context, data *monotonicMapModelV0) diag.Diagnostics {
+	prior := monotonicMapModelV0{
+		Counters: types.MapNull(monotonicMapCounterType),
+		Triggers: types.MapNull(types.MapType{ElemType: types.StringType}),
+	}
+	return m.advance(ctx, prior, data, false)
+}
+
+// advance sets the counters from their prior state. A new key starts at the initial value, a key whose triggers
+// changed increments, and a key which was removed is dropped, leaving the other keys as they were. While planning, a
+// key whose triggers aren't yet known has an unknown history, and an unknown value unless the key is new.
+func (m MonotonicMapResource) advance(ctx context.Context, prior monotonicMapModelV0, data *monotonicMapModelV0, imported bool) diag.Diagnostics {
+	counters := map[string]attr.Value{}
+	values := map[string]attr.Value{}
+	for key, element := range data.Triggers.Elements() {
+		triggers := element.(types.Map)
+		counter, exists := prior.Counters.Elements()[key].(basetypes.ObjectValue)
+		value := data.InitialValue
+		history := types.ListNull(monotonicMapHistoryEntryType)
+		if exists {
+			value = counter.Attributes()["value"].(types.Int64)
+			history = counter.Attributes()["history"].(types.List)
+		}
+		switch {
+		case !mapFullyKnown(triggers):
+			if exists {
+				value = types.Int64Unknown()
+			}
+			history = types.ListUnknown(monotonicMapHistoryEntryType)
+		case !exists:
+			history = appendAndTruncate(ctx, history, m.createHistoryEntry(value, triggers), data.MaxHistory.ValueInt64())
+		case imported:
+			history = replaceLastAndTruncate(ctx, history, m.createHistoryEntry(value, triggers), data.MaxHistory.ValueInt64())
+		case !triggers.Equal(prior.Triggers.Elements()[key]):
+			value = types.Int64Value(value.ValueInt64() + data.Step.ValueInt64())
+			history = appendAndTruncate(ctx, history, m.createHistoryEntry(value, triggers), data.MaxHistory.ValueInt64())
+		}
+		values[key] = value
+		counters[key] = types.ObjectValueMust(monotonicMapCounterType.AttrTypes, map[string]attr.Value{
+			"value":   value,
+			"history": history,
+		})
+	}
+	data.Values = types.MapValueMust(types.Int64Type, values)
+	data.Counters = types.MapValueMust(monotonicMapCounterType, counters)
+	return nil
+}
+
+func (m MonotonicMapResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	data := monotonicMapModelV0{
+		Id:           types.StringValue(uuid.New().String()),
+		Triggers:     types.MapNull(types.MapType{ElemType: types.StringType}),
+		InitialValue: types.Int64Value(0),
+		Step:         types.Int64Value(1),
+		MaxHistory:   types.Int64Value(defaultMaxHistory),
+	}
+
+	// The identifier lists the current value of each key, such as `api=12,web=7`. Each counter is seeded with a
+	// history entry for its value, like the other counters, which adopts the configured triggers on the next apply.
+	counters := map[string]attr.Value{}
+	values := map[string]attr.Value{}
+	for _, pair := range strings.Split(req.ID, ",") {
+		key, raw, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		number, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if !found || key == "" || err != nil {
+			resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: expected `key=value` with a whole number value, got %q. Expected counter values such as `api=12,web=7`.", req.ID, pair))
+			return
+		}
+		if _, duplicate := values[key]; duplicate {
+			resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: the key %q is specified more than once.", req.ID, key))
+			return
+		}
+		value := types.Int64Value(number)
+		history := appendAndTruncate(ctx, types.ListNull(monotonicMapHistoryEntryType), m.createHistoryEntry(value, types.MapNull(types.StringType)), data.MaxHistory.ValueInt64())
+		values[key] = value
+		counters[key] = types.ObjectValueMust(monotonicMapCounterType.AttrTypes, map[string]attr.Value{
+			"value":   value,
+			"history": history,
+		})
+	}
+	data.Values = types.MapValueMust(types.Int64Type, values)
+	data.Counters = types.MapValueMust(monotonicMapCounterType, counters)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
+}
+
+var monotonicMapHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":    types.Int64Type,
+		"triggers": types.MapType{ElemType: types.StringType},
+	},
+}
+
+var monotonicMapCounterType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":   types.Int64Type,
+		"history": types.ListType{ElemType: monotonicMapHistoryEntryType},
+	},
+}
+
+func (m MonotonicMapResource) createHistoryEntry(value types.Int64, triggers types.Map) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		monotonicMapHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"value":    value,
+			"triggers": triggers,
+		},
+	)
+}
+
+type monotonicMapModelV0 struct {
+	Id           types.String `tfsdk:"id"`
+	Values       types.Map    `tfsdk:"values"`
+	Counters     types.Map    `tfsdk:"counters"`
+	Triggers     types.Map    `tfsdk:"triggers"`
+	InitialValue types.Int64  `tfsdk:"initial_value"`
+	Step         types.Int64  `tfsdk:"step"`
+	MaxHistory   types.Int64  `tfsdk:"max_history"`
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"testing"
+)
+
+func monotonicMapStep(triggers string) string {
+	return `
+		resource counter_monotonic_map this {
+			initial_value = 35
+			triggers = {
+				` + triggers + `
+			}
+		}
+	`
+}
+
+func TestAccMonotonicMapResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: monotonicMapStep(`
+					api = { hash = "potatoes" }
+					web = { hash = "potatoes" }
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.api", "35"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.web", "35"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "counters.api.history.0.value", "35"),
+				),
+			},
+			// Only the key whose triggers changed increments
+			{
+				Config: monotonicMapStep(`
+					api = { hash = "eggs" }
+					web = { hash = "potatoes" }
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.api", "36"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.web", "35"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "counters.api.history.#", "2"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "counters.web.history.#", "1"),
+				),
+			},
+			// Adding and removing keys leaves the others alone
+			{
+				Config: monotonicMapStep(`
+					api = { hash = "eggs" }
+					db  = { hash = "bacon" }
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.%", "2"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.api", "36"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.db", "35"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "counters.api.history.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMonotonicMapResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: monotonicMapStep(`api = { hash = "potatoes" }`),
+			},
+			{
+				Config:             monotonicMapStep(`api = { hash = "potatoes" }`),
+				ResourceName:       "counter_monotonic_map.this",
+				ImportState:        true,
+				ImportStateId:      "api=412",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without incrementing
+			{
+				Config: monotonicMapStep(`api = { hash = "potatoes" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.api", "412"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "counters.api.history.#", "1"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "counters.api.history.0.triggers.hash", "potatoes"),
+				),
+			},
+			{
+				Config: monotonicMapStep(`api = { hash = "eggs" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.api", "413"),
+				),
+			},
+		},
+	})
+}
+
+func monotonicMapUnknownTriggersStep(input string, replace string) string {
+	return `
+		resource terraform_data source {
+			input            = "` + input + `"
+			triggers_replace = ["` + replace + `"]
+		}
+
+		resource counter_monotonic_map this {
+			triggers = {
+				api = { hash = terraform_data.source.output }
+				web = { hash = "potatoes" }
+			}
+		}
+	`
+}
+
+func TestAccMonotonicMapResourceUnknownTriggers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: monotonicMapUnknownTriggersStep("potatoes", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.api", "0"),
+				),
+			},
+			// Replacing the source makes the triggers of one key unknown during plan, which only makes the counter of
+			// that key unknown
+			{
+				Config: monotonicMapUnknownTriggersStep("eggs", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("counter_monotonic_map.this", tfjsonpath.New("values").AtMapKey("api")),
+						plancheck.ExpectKnownValue("counter_monotonic_map.this", tfjsonpath.New("values").AtMapKey("web"), knownvalue.Int64Exact(0)),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.api", "1"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "values.web", "0"),
+					resource.TestCheckResourceAttr("counter_monotonic_map.this", "counters.api.history.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &MonotonicNextFunction{}
+
+func NewMonotonicNextFunction() function.Function {
+	return &MonotonicNextFunction{}
+}
+
+type MonotonicNextFunction struct {
+}
+
+func (f MonotonicNextFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "monotonic_next"
+}
+
+func (f MonotonicNextFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Increment a monotonic counter value",
+		MarkdownDescription: "Returns the value which `counter_monotonic` would produce after the next change of its triggers.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "value",
+				MarkdownDescription: "The current value.",
+			},
+			function.Int64Parameter{
+				Name:                "step",
+				MarkdownDescription: "The amount to increment by. Must not be zero.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f MonotonicNextFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value, step int64
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value, &step))
+	if resp.Error != nil {
+		return
+	}
+	if step == 0 {
+		resp.Error = function.NewArgumentFuncError(1, "the step must not be zero")
+		return
+	}
+
+	next := MonotonicResource{}.nextValue(
+		monotonicModelV1{Value: types.Int64Value(value)},
+		monotonicModelV1{Step: types.Int64Value(step), ReusePreviousValues: types.BoolValue(false)},
+	)
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, next.ValueInt64()))
+}
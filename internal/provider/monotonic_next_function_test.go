@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccMonotonicNextFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test increment
+			{
+				Config: `
+					output "next" {
+						value = provider::counter::monotonic_next(4, 3)
+					}
+					output "decrement" {
+						value = provider::counter::monotonic_next(4, -1)
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("next", "7"),
+					resource.TestCheckOutput("decrement", "3"),
+				),
+			},
+			// Test zero step
+			{
+				Config: `
+					output "zero" {
+						value = provider::counter::monotonic_next(4, 0)
+					}
+				`,
+				ExpectError: regexp.MustCompile(`the step must not be zero`),
+			},
+		},
+	})
+}
@@ -2,23 +2,33 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"strconv"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &MonotonicResource{}
 var _ resource.ResourceWithModifyPlan = &MonotonicResource{}
+var _ resource.ResourceWithImportState = &MonotonicResource{}
+var _ resource.ResourceWithConfigValidators = &MonotonicResource{}
 
 func NewMonotonicResource() resource.Resource {
 	return &MonotonicResource{}
@@ -33,6 +43,7 @@ func (m MonotonicResource) Metadata(ctx context.Context, req resource.MetadataRe
 
 func (m MonotonicResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: monotonicSchemaVersion,
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "A monotonic counter which increments according to the configured triggers.",
 		Attributes: map[string]schema.Attribute{
@@ -54,13 +65,19 @@ func (m MonotonicResource) Schema(ctx context.Context, req resource.SchemaReques
 				Optional:            true,
 				Computed:            true,
 				Default:             int64default.StaticInt64(1),
-				MarkdownDescription: "The amount used to increment / decrement the counter on each revision.",
+				MarkdownDescription: "The amount used to increment / decrement the counter on each revision. Must not be zero.",
+				Validators: []validator.Int64{
+					int64validator.NoneOf(0),
+				},
 			},
 			"max_history": schema.Int64Attribute{
 				Computed:            true,
 				Optional:            true,
-				Default:             int64default.StaticInt64(1000),
-				MarkdownDescription: "Maximum number of versions this resource should store in the `history` attribute.",
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of versions this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"history": schema.ListNestedAttribute{
 				Computed:            true,
@@ -70,6 +87,9 @@ func (m MonotonicResource) Schema(ctx context.Context, req resource.SchemaReques
 						"value": schema.Int64Attribute{
 							Computed: true,
 						},
+						"formatted_value": schema.StringAttribute{
+							Computed: true,
+						},
 						"triggers": schema.MapAttribute{
 							ElementType: types.StringType,
 							Computed:    true,
@@ -94,10 +114,48 @@ func (m MonotonicResource) Schema(ctx context.Context, req resource.SchemaReques
 				Optional:            true,
 				MarkdownDescription: "A map of strings that will cause a change to the counter when any of the values change.",
 			},
+			"formatted_value": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current value of the counter rendered with `format_prefix`, `format_radix` and `format_width`, such as `build-000412`.",
+			},
+			"format_width": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The minimum number of digits in `formatted_value`, which is padded with leading zeros. A fixed width keeps the lexical order of values the same as their numeric order. Must be between 0 and 64. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 64),
+				},
+			},
+			"format_radix": schema.StringAttribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             stringdefault.StaticString("decimal"),
+				MarkdownDescription: "The radix of `formatted_value`, one of `decimal`, `hex`, `base36` or `crockford`, which uses the upper case [Crockford base32](https://www.crockford.com/base32.html) alphabet. Defaults to `decimal`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(counterRadixNames...),
+				},
+			},
+			"format_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A prefix for `formatted_value`, such as `build-`.",
+			},
+			"reuse_previous_values": schema.BoolAttribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "When the triggers change back to a combination recorded in `history`, reuse the value produced for it instead of incrementing. New combinations skip any value already recorded in `history`.",
+			},
 		},
 	}
 }
 
+func (m MonotonicResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		triggersConfigured("triggers"),
+	}
+}
+
 func (m MonotonicResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data monotonicModelV1
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -105,6 +163,10 @@ func (m MonotonicResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 	data.Id = types.StringValue(uuid.New().String())
+	resp.Diagnostics.Append(m.initialise(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -113,73 +175,175 @@ func (m MonotonicResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (m MonotonicResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data monotonicModelV1
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	m.lifecycle().update(ctx, req, resp)
 }
 
 func (m MonotonicResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 }
 
 func (m MonotonicResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// If the entire plan is null, the resource is planned for destruction.
-	if req.Plan.Raw.IsNull() {
-		return
-	}
+	m.lifecycle().modifyPlan(ctx, req, resp)
+}
 
-	var value types.Int64
-	var maxHistory types.Int64
-	var triggers types.Map
-	creation := req.State.Raw.IsNull()
+func (m MonotonicResource) lifecycle() triggeredLifecycle[monotonicModelV1] {
+	return triggeredLifecycle[monotonicModelV1]{
+		known:      m.inputsKnown,
+		unknown:    m.setUnknownValue,
+		initialise: m.initialise,
+		advance:    m.advance,
+	}
+}
 
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("triggers"), &triggers)...)
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("max_history"), &maxHistory)...)
+func (m MonotonicResource) inputsKnown(data monotonicModelV1) bool {
+	return mapFullyKnown(data.Triggers) && !data.Step.IsUnknown() && !data.MaxHistory.IsUnknown()
+}
 
+// setUnknownValue plans the value of a new counter, which starts at the initial value whatever the triggers are.
+func (m MonotonicResource) setUnknownValue(data *monotonicModelV1, creation bool) {
 	if creation {
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("initial_value"), &value)...)
-		history := appendAndTruncate([]basetypes.ObjectValue{}, m.createHistoryEntry(value, triggers), maxHistory.ValueInt64())
+		data.Value = data.InitialValue
+	} else {
+		data.Value = types.Int64Unknown()
+	}
+	m.setFormattedValue(data)
+	data.History = types.ListUnknown(monotonicHistoryEntryType)
+}
+
+// initialise sets the value and history of a counter which is being created.
+func (m MonotonicResource) initialise(ctx context.Context, data *monotonicModelV1) diag.Diagnostics {
+	data.Value = data.InitialValue
+	m.setFormattedValue(data)
+	data.History = appendAndTruncate(ctx, types.ListNull(monotonicHistoryEntryType), m.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return nil
+}
+
+// advance sets the value and history of a counter from its prior state, incrementing it when the triggers changed.
+// It is used both while planning and, when the triggers were unknown during planning, while applying.
+func (m MonotonicResource) advance(ctx context.Context, prior monotonicModelV1, data *monotonicModelV1, imported bool) diag.Diagnostics {
+	data.Value = prior.Value
+	m.setFormattedValue(data)
+	data.History = prior.History
+
+	if imported {
+		data.History = replaceLastAndTruncate(ctx, prior.History, m.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+		return nil
+	}
+
+	if !prior.Triggers.Equal(data.Triggers) {
+		data.Value = m.nextValue(prior, *data)
+		m.setFormattedValue(data)
+		data.History = appendAndTruncate(ctx, prior.History, m.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	}
+	return nil
+}
+
+// setFormattedValue renders the value with the format attributes, which is unknown until they all are.
+func (m MonotonicResource) setFormattedValue(data *monotonicModelV1) {
+	if data.Value.IsUnknown() || data.FormatWidth.IsUnknown() || data.FormatRadix.IsUnknown() || data.FormatPrefix.IsUnknown() {
+		data.FormattedValue = types.StringUnknown()
+		return
+	}
+	data.FormattedValue = types.StringValue(formatCounter(data.Value.ValueInt64(), data.FormatWidth.ValueInt64(), data.FormatRadix.ValueString(), data.FormatPrefix.ValueString()))
+}
 
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value"), value)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("history"), history)...)
+func (m MonotonicResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := parseImportId(req.ID, "value", "value", "step", "initial_value", "max_history")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: %s. Expected a counter value such as `412`, or `value=412,step=2,initial_value=0`.", req.ID, err))
 		return
 	}
 
-	if !mapAttributeIsEqual(ctx, req, resp, "triggers") {
-		var step types.Int64
-		var history []basetypes.ObjectValue
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("value"), &value)...)
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("step"), &step)...)
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("history"), &history)...)
+	data := monotonicModelV1{
+		Id:                  types.StringValue(uuid.New().String()),
+		Step:                types.Int64Value(1),
+		MaxHistory:          types.Int64Value(defaultMaxHistory),
+		InitialValue:        types.Int64Value(0),
+		Triggers:            types.MapNull(types.StringType),
+		FormatWidth:         types.Int64Value(0),
+		FormatRadix:         types.StringValue("decimal"),
+		FormatPrefix:        types.StringNull(),
+		ReusePreviousValues: types.BoolValue(false),
+	}
+	for attribute, raw := range values {
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Import Identifier", fmt.Sprintf("The value %q for %s is not a whole number.", raw, attribute))
+			continue
+		}
+		switch attribute {
+		case "value":
+			data.Value = types.Int64Value(number)
+		case "step":
+			data.Step = types.Int64Value(number)
+		case "initial_value":
+			data.InitialValue = types.Int64Value(number)
+		case "max_history":
+			data.MaxHistory = types.Int64Value(number)
+		}
+	}
+	if data.Step.ValueInt64() == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("step"), "Invalid Import Identifier", "The step must not be zero.")
+	}
+	if data.MaxHistory.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_history"), "Invalid Import Identifier", "The max_history must be at least 1.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	m.setFormattedValue(&data)
+	data.History = appendAndTruncate(ctx, types.ListNull(monotonicHistoryEntryType), m.createHistoryEntry(data), data.MaxHistory.ValueInt64())
 
-		value = types.Int64Value(value.ValueInt64() + step.ValueInt64())
-		history = appendAndTruncate(history, m.createHistoryEntry(value, triggers), maxHistory.ValueInt64())
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value"), value)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("history"), history)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
+}
+
+// nextValue returns the value for a change of triggers. Values are reused for previously seen triggers when
+// reuse_previous_values is enabled, in which case new triggers never receive a value another entry already holds.
+func (m MonotonicResource) nextValue(prior monotonicModelV1, data monotonicModelV1) types.Int64 {
+	value := types.Int64Value(prior.Value.ValueInt64() + data.Step.ValueInt64())
+	if !data.ReusePreviousValues.ValueBool() {
+		return value
+	}
+
+	if entry, found := findHistoryEntry(prior.History, map[string]attr.Value{"triggers": data.Triggers}); found {
+		return entry.Attributes()["value"].(types.Int64)
 	}
+	for historyContains(prior.History, "value", value) {
+		value = types.Int64Value(value.ValueInt64() + data.Step.ValueInt64())
+	}
+	return value
+}
+
+var monotonicHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":           types.Int64Type,
+		"formatted_value": types.StringType,
+		"triggers":        types.MapType{ElemType: types.StringType},
+	},
 }
 
-func (m MonotonicResource) createHistoryEntry(value types.Int64, triggers types.Map) basetypes.ObjectValue {
+func (m MonotonicResource) createHistoryEntry(data monotonicModelV1) basetypes.ObjectValue {
 	return types.ObjectValueMust(
-		map[string]attr.Type{
-			"value":    types.Int64Type,
-			"triggers": types.MapType{ElemType: types.StringType},
-		},
+		monotonicHistoryEntryType.AttrTypes,
 		map[string]attr.Value{
-			"value":    value,
-			"triggers": triggers,
+			"value":           data.Value,
+			"formatted_value": data.FormattedValue,
+			"triggers":        data.Triggers,
 		},
 	)
 }
 
 type monotonicModelV1 struct {
-	Id           types.String            `tfsdk:"id"`
-	Value        types.Int64             `tfsdk:"value"`
-	Step         types.Int64             `tfsdk:"step"`
-	MaxHistory   types.Int64             `tfsdk:"max_history"`
-	History      []basetypes.ObjectValue `tfsdk:"history"`
-	InitialValue types.Int64             `tfsdk:"initial_value"`
-	Triggers     types.Map               `tfsdk:"triggers"`
+	Id                  types.String `tfsdk:"id"`
+	Value               types.Int64  `tfsdk:"value"`
+	Step                types.Int64  `tfsdk:"step"`
+	MaxHistory          types.Int64  `tfsdk:"max_history"`
+	History             types.List   `tfsdk:"history"`
+	InitialValue        types.Int64  `tfsdk:"initial_value"`
+	Triggers            types.Map    `tfsdk:"triggers"`
+	FormattedValue      types.String `tfsdk:"formatted_value"`
+	FormatWidth         types.Int64  `tfsdk:"format_width"`
+	FormatRadix         types.String `tfsdk:"format_radix"`
+	FormatPrefix        types.String `tfsdk:"format_prefix"`
+	ReusePreviousValues types.Bool   `tfsdk:"reuse_previous_values"`
 }
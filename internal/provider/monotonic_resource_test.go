@@ -2,6 +2,9 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"regexp"
 	"testing"
 )
 
@@ -74,3 +77,276 @@ func TestAccMonotonicResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccMonotonicResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: step1(),
+			},
+			// Import an existing counter value
+			{
+				Config:             step1(),
+				ResourceName:       "counter_monotonic.this",
+				ImportState:        true,
+				ImportStateId:      "value=412,initial_value=35",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without incrementing
+			{
+				Config: step1(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "412"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.#", "1"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.0.value", "412"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.0.triggers.hash", "potatoes"),
+				),
+			},
+			{
+				Config: step2(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "413"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.1.value", "413"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMonotonicResourceImportWithoutTriggers(t *testing.T) {
+	withoutTriggers := `
+		resource counter_monotonic this {
+		}
+	`
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withoutTriggers,
+			},
+			{
+				Config:             withoutTriggers,
+				ResourceName:       "counter_monotonic.this",
+				ImportState:        true,
+				ImportStateId:      "412",
+				ImportStatePersist: true,
+			},
+			// The seeded history entry already matches a configuration without triggers, so there is nothing to change
+			{
+				Config: withoutTriggers,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "412"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.#", "1"),
+				),
+			},
+			// The first triggers configured for the imported counter are adopted
+			{
+				Config: `
+					resource counter_monotonic this {
+						triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "412"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.#", "1"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.0.triggers.hash", "potatoes"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMonotonicResourceValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource counter_monotonic this {
+						max_history = -1
+						triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`max_history value must be at least 1`),
+			},
+			{
+				Config: `
+					resource counter_monotonic this {
+						step = 0
+						triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`step value must be none of`),
+			},
+		},
+	})
+}
+
+func unknownTriggersStep(input string, replace string) string {
+	return `
+		resource terraform_data source {
+			input            = "` + input + `"
+			triggers_replace = ["` + replace + `"]
+		}
+
+		resource counter_monotonic this {
+			triggers = {
+				hash = terraform_data.source.output
+			}
+		}
+	`
+}
+
+func TestAccMonotonicResourceUnknownTriggers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: unknownTriggersStep("potatoes", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "0"),
+				),
+			},
+			// Replacing the source makes the value of a trigger unknown during plan, so the plan can't tell whether the
+			// counter changes. The trigger resolves to the same value when applied.
+			{
+				Config: unknownTriggersStep("potatoes", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("counter_monotonic.this", tfjsonpath.New("value")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "0"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.#", "1"),
+				),
+			},
+			// Unknown triggers which resolve to a different value increment the counter
+			{
+				Config: unknownTriggersStep("eggs", "3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "1"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.#", "2"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.1.triggers.hash", "eggs"),
+				),
+			},
+		},
+	})
+}
+
+func reuseStep(hash string) string {
+	return `
+		resource counter_monotonic this {
+			reuse_previous_values = true
+			triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccMonotonicResourceReusePreviousValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: reuseStep("potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "0"),
+				),
+			},
+			{
+				Config: reuseStep("eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "1"),
+				),
+			},
+			// Reverting the triggers reuses the value produced for them
+			{
+				Config: reuseStep("potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "0"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.#", "3"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.2.value", "0"),
+				),
+			},
+			// New triggers skip values which are already taken
+			{
+				Config: reuseStep("bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "2"),
+				),
+			},
+		},
+	})
+}
+
+func formatMonotonicStep(hash string, radix string) string {
+	return `
+		resource counter_monotonic this {
+			initial_value = 412
+			format_width = 6
+			format_radix = "` + radix + `"
+			format_prefix = "build-"
+			triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccMonotonicResourceFormat(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test padding
+			{
+				Config: formatMonotonicStep("eggs", "decimal"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "formatted_value", "build-000412"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.0.formatted_value", "build-000412"),
+				),
+			},
+			// Test radix change does not increment
+			{
+				Config: formatMonotonicStep("eggs", "hex"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "value", "412"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "formatted_value", "build-00019c"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.#", "1"),
+				),
+			},
+			// Test increment
+			{
+				Config: formatMonotonicStep("bacon", "crockford"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_monotonic.this", "formatted_value", "build-0000CX"),
+					resource.TestCheckResourceAttr("counter_monotonic.this", "history.1.formatted_value", "build-0000CX"),
+				),
+			},
+			// Test unsupported radix
+			{
+				Config:      formatMonotonicStep("bacon", "octal"),
+				ExpectError: regexp.MustCompile(`format_radix`),
+			},
+		},
+	})
+}
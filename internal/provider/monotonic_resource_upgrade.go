@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ resource.ResourceWithUpgradeState = &MonotonicResource{}
+
+// monotonicSchemaVersion is the current version of the counter_monotonic schema. Bump it, freeze the previous schema
+// and model below and add an upgrader from the previous version whenever the stored layout changes.
+const monotonicSchemaVersion = 1
+
+func (m MonotonicResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := monotonicSchemaV0()
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior monotonicModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				upgraded := m.upgradeStateV0(prior)
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
+	}
+}
+
+// upgradeStateV0 converts state written before the schema was versioned. Such state may lack history or hold more
+// entries than max_history allows, so the history is seeded from the current value and truncated.
+func (m MonotonicResource) upgradeStateV0(prior monotonicModelV0) monotonicModelV1 {
+	maxHistory := prior.MaxHistory
+	if maxHistory.IsNull() || maxHistory.ValueInt64() < 1 {
+		maxHistory = types.Int64Value(defaultMaxHistory)
+	}
+	upgraded := monotonicModelV1{
+		Id:                  prior.Id,
+		Value:               prior.Value,
+		Step:                prior.Step,
+		MaxHistory:          maxHistory,
+		InitialValue:        prior.InitialValue,
+		Triggers:            prior.Triggers,
+		FormatWidth:         types.Int64Value(0),
+		FormatRadix:         types.StringValue("decimal"),
+		FormatPrefix:        types.StringNull(),
+		ReusePreviousValues: types.BoolValue(false),
+	}
+	m.setFormattedValue(&upgraded)
+
+	history := make([]attr.Value, 0, len(prior.History))
+	for _, entry := range prior.History {
+		attributes := entry.Attributes()
+		entryData := upgraded
+		entryData.Value = attributes["value"].(types.Int64)
+		entryData.Triggers = attributes["triggers"].(types.Map)
+		m.setFormattedValue(&entryData)
+		history = append(history, m.createHistoryEntry(entryData))
+	}
+	if len(history) == 0 {
+		history = append(history, m.createHistoryEntry(upgraded))
+	}
+	upgraded.History = types.ListValueMust(monotonicHistoryEntryType, truncate(history, maxHistory.ValueInt64()))
+	return upgraded
+}
+
+func monotonicSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"value": schema.Int64Attribute{
+				Computed: true,
+			},
+			"step": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+			},
+			"max_history": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+			},
+			"history": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.Int64Attribute{
+							Computed: true,
+						},
+						"triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"initial_value": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+			},
+			"triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+type monotonicModelV0 struct {
+	Id           types.String            `tfsdk:"id"`
+	Value        types.Int64             `tfsdk:"value"`
+	Step         types.Int64             `tfsdk:"step"`
+	MaxHistory   types.Int64             `tfsdk:"max_history"`
+	History      []basetypes.ObjectValue `tfsdk:"history"`
+	InitialValue types.Int64             `tfsdk:"initial_value"`
+	Triggers     types.Map               `tfsdk:"triggers"`
+}
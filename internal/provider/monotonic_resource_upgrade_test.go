@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMonotonicResourceUpgradeStateV0(t *testing.T) {
+	state := testUpgradeResourceState(t, "counter_monotonic", 0, `{
+		"id": "c0ffee",
+		"value": 36,
+		"step": 1,
+		"max_history": 2,
+		"initial_value": 34,
+		"triggers": {"hash": "bacon"},
+		"history": [
+			{"value": 34, "triggers": {"hash": "potatoes"}},
+			{"value": 35, "triggers": {"hash": "eggs"}},
+			{"value": 36, "triggers": {"hash": "bacon"}}
+		]
+	}`)
+
+	if value := testStateAttribute(t, state, tftypes.AttributeName("value")); !value.Equal(tftypes.NewValue(tftypes.Number, big.NewFloat(36))) {
+		t.Errorf("expected value 36, got %s", value)
+	}
+	var history []tftypes.Value
+	if err := testStateAttribute(t, state, tftypes.AttributeName("history")).As(&history); err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected history to be truncated to 2 entries, got %d", len(history))
+	}
+	if value := testStateAttribute(t, state, tftypes.AttributeName("history"), tftypes.ElementKeyInt(0), tftypes.AttributeName("value")); !value.Equal(tftypes.NewValue(tftypes.Number, big.NewFloat(35))) {
+		t.Errorf("expected oldest retained history value 35, got %s", value)
+	}
+}
+
+func TestMonotonicResourceUpgradeStateV0WithoutHistory(t *testing.T) {
+	state := testUpgradeResourceState(t, "counter_monotonic", 0, `{
+		"id": "c0ffee",
+		"value": 7,
+		"step": 1,
+		"max_history": 1000,
+		"initial_value": 0,
+		"triggers": {"hash": "bacon"},
+		"history": null
+	}`)
+
+	if value := testStateAttribute(t, state, tftypes.AttributeName("history"), tftypes.ElementKeyInt(0), tftypes.AttributeName("value")); !value.Equal(tftypes.NewValue(tftypes.Number, big.NewFloat(7))) {
+		t.Errorf("expected seeded history value 7, got %s", value)
+	}
+	if value := testStateAttribute(t, state, tftypes.AttributeName("history"), tftypes.ElementKeyInt(0), tftypes.AttributeName("triggers"), tftypes.ElementKeyString("hash")); !value.Equal(tftypes.NewValue(tftypes.String, "bacon")) {
+		t.Errorf("expected seeded history triggers, got %s", value)
+	}
+}
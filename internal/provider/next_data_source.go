@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NextDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &NextDataSource{}
+
+func NewNextDataSource() datasource.DataSource {
+	return &NextDataSource{}
+}
+
+type NextDataSource struct {
+}
+
+func (n NextDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_next"
+}
+
+func (n NextDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	triggers := func(description string) schema.MapAttribute {
+		return schema.MapAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			MarkdownDescription: description,
+		}
+	}
+	semanticTriggers := func(description string) schema.SingleNestedAttribute {
+		return schema.SingleNestedAttribute{
+			Optional:            true,
+			MarkdownDescription: description,
+			Attributes: map[string]schema.Attribute{
+				"major":      triggers("The `major_triggers`."),
+				"minor":      triggers("The `minor_triggers`."),
+				"patch":      triggers("The `patch_triggers`."),
+				"prerelease": triggers("The `prerelease_triggers`."),
+				"stabilize":  triggers("The `stabilize_triggers`."),
+			},
+		}
+	}
+	step := func(component string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("The `%s_step`. Defaults to 1.", component),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Previews the value which `counter_monotonic` or `counter_semantic_version` would produce for a change of triggers, without any state. Exactly one of `monotonic` or `semantic_version` must be set.",
+		Attributes: map[string]schema.Attribute{
+			"monotonic": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Preview a `counter_monotonic` value.",
+				Attributes: map[string]schema.Attribute{
+					"value": schema.Int64Attribute{
+						Required:            true,
+						MarkdownDescription: "The current value of the counter.",
+					},
+					"step": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "The amount used to increment / decrement the counter. Must not be zero. Defaults to 1.",
+						Validators: []validator.Int64{
+							int64validator.NoneOf(0),
+						},
+					},
+					"previous_triggers": triggers("The triggers which produced the current value."),
+					"triggers":          triggers("The proposed triggers."),
+					"next_value": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "The value the counter would have with the proposed triggers.",
+					},
+					"changed": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the proposed triggers would change the value.",
+					},
+				},
+			},
+			"semantic_version": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Preview a `counter_semantic_version` value.",
+				Attributes: map[string]schema.Attribute{
+					"value": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The current version.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(semanticVersionPattern, "must be a semantic version"),
+						},
+					},
+					"previous_triggers": semanticTriggers("The triggers which produced the current version."),
+					"triggers":          semanticTriggers("The proposed triggers."),
+					"prerelease_channel": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "The proposed `prerelease_channel`.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(prereleaseChannelPattern, "must be an alphanumeric SemVer prerelease identifier"),
+						},
+					},
+					"initial_development": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "The `initial_development` setting. Defaults to false.",
+					},
+					"major_step": step("major"),
+					"minor_step": step("minor"),
+					"patch_step": step("patch"),
+					"reset_lower_components": schema.MapAttribute{
+						ElementType:         types.BoolType,
+						Optional:            true,
+						MarkdownDescription: "The `reset_lower_components` setting.",
+						Validators: []validator.Map{
+							mapvalidator.KeysAre(stringvalidator.OneOf("major", "minor")),
+						},
+					},
+					"simultaneous_changes": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "The `simultaneous_changes` setting. Defaults to `highest_wins`.",
+						Validators: []validator.String{
+							stringvalidator.OneOf("highest_wins", "cumulative"),
+						},
+					},
+					"next_value": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The version which would be produced with the proposed triggers.",
+					},
+					"level": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The highest level which would change, one of `major`, `minor`, `patch`, `prerelease` or `release`, or null when the version wouldn't change.",
+					},
+					"changed": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the proposed triggers would change the version.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (n NextDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("monotonic"), path.MatchRoot("semantic_version")),
+	}
+}
+
+func (n NextDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data nextModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Monotonic != nil {
+		n.readMonotonic(ctx, data.Monotonic)
+	}
+	if data.SemanticVersion != nil {
+		resp.Diagnostics.Append(n.readSemanticVersion(ctx, data.SemanticVersion)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readMonotonic advances a counter_monotonic model built from the configuration, so the preview always matches the
+// resource.
+func (n NextDataSource) readMonotonic(ctx context.Context, data *nextMonotonicModel) {
+	step := data.Step
+	if step.IsNull() {
+		step = types.Int64Value(1)
+	}
+	prior := monotonicModelV1{
+		Value:    data.Value,
+		Triggers: data.PreviousTriggers,
+		History:  types.ListNull(monotonicHistoryEntryType),
+	}
+	next := monotonicModelV1{
+		Step:                step,
+		MaxHistory:          types.Int64Value(1),
+		Triggers:            data.Triggers,
+		ReusePreviousValues: types.BoolValue(false),
+	}
+	MonotonicResource{}.advance(ctx, prior, &next, false)
+
+	data.NextValue = next.Value
+	data.Changed = types.BoolValue(!next.Value.Equal(data.Value))
+}
+
+// readSemanticVersion advances a counter_semantic_version model built from the configuration, so the preview always
+// matches the resource.
+func (n NextDataSource) readSemanticVersion(ctx context.Context, data *nextSemanticVersionModel) diag.Diagnostics {
+	previous := data.PreviousTriggers
+	if previous == nil {
+		previous = nullSemanticTriggers()
+	}
+	proposed := data.Triggers
+	if proposed == nil {
+		proposed = nullSemanticTriggers()
+	}
+	stepOrDefault := func(step types.Int64) types.Int64 {
+		if step.IsNull() {
+			return types.Int64Value(1)
+		}
+		return step
+	}
+
+	prior := semanticVersionModelV1{
+		Value:              data.Value,
+		History:            types.ListNull(semanticVersionHistoryEntryType),
+		MajorTriggers:      previous.Major,
+		MinorTriggers:      previous.Minor,
+		PatchTriggers:      previous.Patch,
+		PrereleaseTriggers: previous.Prerelease,
+		StabilizeTriggers:  previous.Stabilize,
+	}
+	next := semanticVersionModelV1{
+		MaxHistory:           types.Int64Value(1),
+		MajorTriggers:        proposed.Major,
+		MinorTriggers:        proposed.Minor,
+		PatchTriggers:        proposed.Patch,
+		PrereleaseTriggers:   proposed.Prerelease,
+		StabilizeTriggers:    proposed.Stabilize,
+		PrereleaseChannel:    data.PrereleaseChannel,
+		InitialDevelopment:   data.InitialDevelopment,
+		MajorStep:            stepOrDefault(data.MajorStep),
+		MinorStep:            stepOrDefault(data.MinorStep),
+		PatchStep:            stepOrDefault(data.PatchStep),
+		ResetLowerComponents: data.ResetLowerComponents,
+		SimultaneousChanges:  data.SimultaneousChanges,
+		BuildMetadata:        types.StringNull(),
+		Format:               types.StringValue("semver"),
+		ReusePreviousValues:  types.BoolValue(false),
+	}
+	diags := SemanticVersionResource{}.advance(ctx, prior, &next, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	current, _ := parseSemanticVersion(data.Value.ValueString())
+	proposedVersion, _ := parseSemanticVersion(next.Value.ValueString())
+	data.NextValue = next.Value
+	data.Level = types.StringNull()
+	if level := semanticVersionChangeLevel(current, proposedVersion); level != "" {
+		data.Level = types.StringValue(level)
+	}
+	data.Changed = types.BoolValue(!data.Level.IsNull())
+	return diags
+}
+
+// semanticVersionChangeLevel returns the highest component which differs between two versions, or "" when only build
+// metadata differs.
+func semanticVersionChangeLevel(from semanticVersion, to semanticVersion) string {
+	switch {
+	case from.major != to.major:
+		return "major"
+	case from.minor != to.minor:
+		return "minor"
+	case from.patch != to.patch:
+		return "patch"
+	case from.compare(to) == 0:
+		return ""
+	case !to.isPrerelease():
+		return "release"
+	}
+	return "prerelease"
+}
+
+type nextModel struct {
+	Monotonic       *nextMonotonicModel       `tfsdk:"monotonic"`
+	SemanticVersion *nextSemanticVersionModel `tfsdk:"semantic_version"`
+}
+
+type nextMonotonicModel struct {
+	Value            types.Int64 `tfsdk:"value"`
+	Step             types.Int64 `tfsdk:"step"`
+	PreviousTriggers types.Map   `tfsdk:"previous_triggers"`
+	Triggers         types.Map   `tfsdk:"triggers"`
+	NextValue        types.Int64 `tfsdk:"next_value"`
+	Changed          types.Bool  `tfsdk:"changed"`
+}
+
+type nextSemanticVersionModel struct {
+	Value                types.String               `tfsdk:"value"`
+	PreviousTriggers     *nextSemanticTriggersModel `tfsdk:"previous_triggers"`
+	Triggers             *nextSemanticTriggersModel `tfsdk:"triggers"`
+	PrereleaseChannel    types.String               `tfsdk:"prerelease_channel"`
+	InitialDevelopment   types.Bool                 `tfsdk:"initial_development"`
+	MajorStep            types.Int64                `tfsdk:"major_step"`
+	MinorStep            types.Int64                `tfsdk:"minor_step"`
+	PatchStep            types.Int64                `tfsdk:"patch_step"`
+	ResetLowerComponents types.Map                  `tfsdk:"reset_lower_components"`
+	SimultaneousChanges  types.String               `tfsdk:"simultaneous_changes"`
+	NextValue            types.String               `tfsdk:"next_value"`
+	Level                types.String               `tfsdk:"level"`
+	Changed              types.Bool                 `tfsdk:"changed"`
+}
+
+// nullSemanticTriggers returns the triggers for an omitted previous_triggers or triggers attribute.
+func nullSemanticTriggers() *nextSemanticTriggersModel {
+	return &nextSemanticTriggersModel{
+		Major:      types.MapNull(types.StringType),
+		Minor:      types.MapNull(types.StringType),
+		Patch:      types.MapNull(types.StringType),
+		Prerelease: types.MapNull(types.StringType),
+		Stabilize:  types.MapNull(types.StringType),
+	}
+}
+
+type nextSemanticTriggersModel struct {
+	Major      types.Map `tfsdk:"major"`
+	Minor      types.Map `tfsdk:"minor"`
+	Patch      types.Map `tfsdk:"patch"`
+	Prerelease types.Map `tfsdk:"prerelease"`
+	Stabilize  types.Map `tfsdk:"stabilize"`
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNextDataSourceMonotonic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data counter_next this {
+						monotonic = {
+							value = 4
+							step = 3
+							previous_triggers = {
+								hash = "eggs"
+							}
+							triggers = {
+								hash = "bacon"
+							}
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.counter_next.this", "monotonic.next_value", "7"),
+					resource.TestCheckResourceAttr("data.counter_next.this", "monotonic.changed", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNextDataSourceSemanticVersion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test the highest change wins
+			{
+				Config: `
+					data counter_next this {
+						semantic_version = {
+							value = "1.2.3"
+							previous_triggers = {
+								minor = {
+									hash = "eggs"
+								}
+							}
+							triggers = {
+								major = {
+									hash = "bacon"
+								}
+								minor = {
+									hash = "bacon"
+								}
+							}
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.counter_next.this", "semantic_version.next_value", "2.0.0"),
+					resource.TestCheckResourceAttr("data.counter_next.this", "semantic_version.level", "major"),
+					resource.TestCheckResourceAttr("data.counter_next.this", "semantic_version.changed", "true"),
+				),
+			},
+			// Test promotion
+			{
+				Config: `
+					data counter_next this {
+						semantic_version = {
+							value = "1.2.3-rc.2"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.counter_next.this", "semantic_version.next_value", "1.2.3"),
+					resource.TestCheckResourceAttr("data.counter_next.this", "semantic_version.level", "release"),
+				),
+			},
+			// Test no change
+			{
+				Config: `
+					data counter_next this {
+						semantic_version = {
+							value = "1.2.3"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.counter_next.this", "semantic_version.next_value", "1.2.3"),
+					resource.TestCheckNoResourceAttr("data.counter_next.this", "semantic_version.level"),
+					resource.TestCheckResourceAttr("data.counter_next.this", "semantic_version.changed", "false"),
+				),
+			},
+			// Test neither counter
+			{
+				Config: `
+					data counter_next this {
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Exactly one of these attributes must be configured`),
+			},
+		},
+	})
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// debianUpstreamVersionPattern matches the upstream part of a Debian version, which must start with a digit. Hyphens
+// are allowed as the version always has a revision, and colons aren't as the epoch is managed separately.
+var debianUpstreamVersionPattern = regexp.MustCompile(`^[0-9][A-Za-z0-9.+~-]*$`)
+
+// debianRevisionSuffixPattern matches the characters allowed in a Debian revision.
+var debianRevisionSuffixPattern = regexp.MustCompile(`^[A-Za-z0-9.+~]*$`)
+
+// rpmVersionPattern matches the characters allowed in the version and release of an RPM package.
+var rpmVersionPattern = regexp.MustCompile(`^[A-Za-z0-9._+~^]*$`)
+
+// packageVersionPattern splits a package version into its epoch, upstream version, revision number and any revision
+// suffix, such as `1:2.4.1-3.el9`.
+var packageVersionPattern = regexp.MustCompile(`^(?:([0-9]+):)?(.+)-([0-9]+)([^-]*)$`)
+
+type packageVersion struct {
+	epoch    int64
+	upstream string
+	revision string
+}
+
+func (v packageVersion) String() string {
+	if v.epoch != 0 {
+		return fmt.Sprintf("%d:%s-%s", v.epoch, v.upstream, v.revision)
+	}
+	return v.upstream + "-" + v.revision
+}
+
+// compare returns -1, 0 or 1 by comparing the epochs numerically and the upstream versions and revisions with the
+// rules of the package format.
+func (v packageVersion) compare(other packageVersion, format string) int {
+	if v.epoch != other.epoch {
+		if v.epoch < other.epoch {
+			return -1
+		}
+		return 1
+	}
+	compare := compareDebianVersionPart
+	if format == "rpm" {
+		compare = compareRPMVersionPart
+	}
+	if result := compare(v.upstream, other.upstream); result != 0 {
+		return result
+	}
+	return compare(v.revision, other.revision)
+}
+
+// validatePackageVersionPart checks that a part of a version, such as the upstream version or revision suffix, only
+// uses the characters the package format allows.
+func validatePackageVersionPart(value string, format string, upstream bool) error {
+	switch {
+	case format == "rpm" && (!rpmVersionPattern.MatchString(value) || upstream && value == ""):
+		return fmt.Errorf("%q may only contain letters, digits and `.`, `_`, `+`, `~` or `^`", value)
+	case format == "deb" && upstream && !debianUpstreamVersionPattern.MatchString(value):
+		return fmt.Errorf("%q must start with a digit and may only contain letters, digits and `.`, `+`, `~` or `-`", value)
+	case format == "deb" && !upstream && !debianRevisionSuffixPattern.MatchString(value):
+		return fmt.Errorf("%q may only contain letters, digits and `.`, `+` or `~`", value)
+	}
+	return nil
+}
+
+// debianOrder returns the sort weight of a character in a non-digit part of a Debian version, where `~` sorts before
+// anything, even the end of the part, and letters sort before other characters.
+func debianOrder(value string, i int) int {
+	if i >= len(value) {
+		return 0
+	}
+	c := value[i]
+	switch {
+	case c == '~':
+		return -1
+	case isDigit(c):
+		return 0
+	case c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z':
+		return int(c)
+	}
+	return int(c) + 256
+}
+
+// compareDebianVersionPart compares upstream versions or revisions with the algorithm of dpkg's verrevcmp, alternating
+// between non-digit parts compared by debianOrder and digit parts compared numerically, see
+// https://www.debian.org/doc/debian-policy/ch-controlfields.html#version.
+func compareDebianVersionPart(a string, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isDigit(a[i]) || j < len(b) && !isDigit(b[j]) {
+			if x, y := debianOrder(a, i), debianOrder(b, j); x != y {
+				return sign(x - y)
+			}
+			i++
+			j++
+		}
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		firstDifference := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDifference == 0 {
+				firstDifference = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDifference != 0 {
+			return sign(firstDifference)
+		}
+	}
+	return 0
+}
+
+// compareRPMVersionPart compares versions or releases with the algorithm of rpmvercmp, which compares runs of digits
+// numerically and runs of letters lexically, ignoring separators. `~` sorts before anything and `^` sorts after the
+// end of the part but before anything else.
+func compareRPMVersionPart(a string, b string) int {
+	if a == b {
+		return 0
+	}
+	isSeparator := func(c byte) bool {
+		return !isDigit(c) && !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z') && c != '~' && c != '^'
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && isSeparator(a[i]) {
+			i++
+		}
+		for j < len(b) && isSeparator(b[j]) {
+			j++
+		}
+
+		tildeA, tildeB := i < len(a) && a[i] == '~', j < len(b) && b[j] == '~'
+		if tildeA || tildeB {
+			if !tildeA {
+				return 1
+			}
+			if !tildeB {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+		caretA, caretB := i < len(a) && a[i] == '^', j < len(b) && b[j] == '^'
+		if caretA || caretB {
+			switch {
+			case i >= len(a):
+				return -1
+			case j >= len(b):
+				return 1
+			case !caretA:
+				return 1
+			case !caretB:
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		startA, startB := i, j
+		numeric := isDigit(a[i])
+		segment := func(value string, k int) int {
+			for k < len(value) && (numeric && isDigit(value[k]) || !numeric && !isDigit(value[k]) && !isSeparator(value[k]) && value[k] != '~' && value[k] != '^') {
+				k++
+			}
+			return k
+		}
+		i, j = segment(a, i), segment(b, j)
+		if j == startB {
+			// Numeric segments are newer than alphabetic ones.
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+
+		x, y := a[startA:i], b[startB:j]
+		if numeric {
+			x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+			if len(x) != len(y) {
+				return sign(len(x) - len(y))
+			}
+		}
+		if result := strings.Compare(x, y); result != 0 {
+			return result
+		}
+	}
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i >= len(a):
+		return -1
+	}
+	return 1
+}
+
+// parsePackageVersion parses a version in `[<epoch>:]<upstream>-<revision>` form, where the revision starts with a
+// number.
+func parsePackageVersion(value string, format string) (packageVersion, int64, string, error) {
+	matches := packageVersionPattern.FindStringSubmatch(value)
+	if matches == nil {
+		return packageVersion{}, 0, "", fmt.Errorf("%q is not in `[<epoch>:]<upstream>-<revision>` form with a numeric revision", value)
+	}
+	var version packageVersion
+	var err error
+	if matches[1] != "" {
+		if version.epoch, err = strconv.ParseInt(matches[1], 10, 64); err != nil {
+			return packageVersion{}, 0, "", fmt.Errorf("%q has an invalid epoch: %w", value, err)
+		}
+	}
+	revision, err := strconv.ParseInt(matches[3], 10, 64)
+	if err != nil {
+		return packageVersion{}, 0, "", fmt.Errorf("%q has an invalid revision: %w", value, err)
+	}
+	if err := validatePackageVersionPart(matches[2], format, true); err != nil {
+		return packageVersion{}, 0, "", err
+	}
+	if err := validatePackageVersionPart(matches[4], format, false); err != nil {
+		return packageVersion{}, 0, "", err
+	}
+	version.upstream = matches[2]
+	version.revision = matches[3] + matches[4]
+	return version, revision, matches[4], nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func sign(value int) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,397 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"strconv"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PackageVersionResource{}
+var _ resource.ResourceWithModifyPlan = &PackageVersionResource{}
+var _ resource.ResourceWithImportState = &PackageVersionResource{}
+var _ resource.ResourceWithValidateConfig = &PackageVersionResource{}
+
+func NewPackageVersionResource() resource.Resource {
+	return &PackageVersionResource{}
+}
+
+type PackageVersionResource struct {
+}
+
+func (p PackageVersionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_package_version"
+}
+
+func (p PackageVersionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A `[<epoch>:]<upstream>-<revision>` version for Debian or RPM packages, whose revision starts over when the upstream version changes and increments for packaging-only changes. Every new version sorts after the previous one under the comparison rules of the package format.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The version as a string in `[<epoch>:]<upstream>-<revision>` form, where the epoch is omitted when it is 0.",
+			},
+			"epoch_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current epoch.",
+			},
+			"revision_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current revision number, without `revision_suffix`.",
+			},
+			"full_revision": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current revision including the `revision_suffix` it was produced with, such as `3.el9`, for the `Release` field of an RPM spec or the revision in a Debian changelog.",
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of versions this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"history": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "A list of versions that this resource has produced, in ascending order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+						"upstream_version": schema.StringAttribute{
+							Computed: true,
+						},
+						"epoch_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"revision_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"format": schema.StringAttribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             stringdefault.StaticString("deb"),
+				MarkdownDescription: "The package format, which decides the characters allowed in the version and how versions are ordered. With `deb` versions are compared like dpkg does, and with `rpm` like rpmvercmp does. Both sort `~` before anything else, such as `1.0~rc1` before `1.0`. Defaults to `deb`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("deb", "rpm"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"upstream_version": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The version of the packaged software, such as `2.4.1`. Changing it starts the revision over at `revision_initial_value`, and the new version must sort after the current one unless `epoch_triggers` changes at the same time.",
+			},
+			"revision_suffix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A suffix appended to revisions produced from now on, such as `.el9` for an RPM dist tag or `~bpo12+1` for a Debian backport. Changing it doesn't produce a new version by itself.",
+			},
+			"epoch_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The initial epoch. Must not be negative. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"revision_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "The revision number for each new upstream version or epoch. Must not be negative. Defaults to 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"epoch_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the epoch to increment and the revision to start over when any of the values change. A new epoch lets the upstream version go backwards, or change to a scheme which sorts lower.",
+			},
+			"revision_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the revision to increment when any of the values change, such as a hash of the packaging files.",
+			},
+		},
+	}
+}
+
+func (p PackageVersionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data packageVersionModelV0
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Format.IsUnknown() {
+		return
+	}
+	format := data.Format.ValueString()
+	if data.Format.IsNull() {
+		format = "deb"
+	}
+
+	if !data.UpstreamVersion.IsNull() && !data.UpstreamVersion.IsUnknown() {
+		if err := validatePackageVersionPart(data.UpstreamVersion.ValueString(), format, true); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("upstream_version"), "Invalid Upstream Version", fmt.Sprintf("The upstream version %s in %s versions.", err, format))
+		}
+	}
+	if !data.RevisionSuffix.IsNull() && !data.RevisionSuffix.IsUnknown() {
+		if err := validatePackageVersionPart(data.RevisionSuffix.ValueString(), format, false); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("revision_suffix"), "Invalid Revision Suffix", fmt.Sprintf("The revision suffix %s in %s versions.", err, format))
+		}
+	}
+}
+
+func (p PackageVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data packageVersionModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	resp.Diagnostics.Append(p.initialise(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (p PackageVersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (p PackageVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	p.lifecycle().update(ctx, req, resp)
+}
+
+func (p PackageVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (p PackageVersionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	p.lifecycle().modifyPlan(ctx, req, resp)
+}
+
+func (p PackageVersionResource) lifecycle() triggeredLifecycle[packageVersionModelV0] {
+	return triggeredLifecycle[packageVersionModelV0]{
+		known:      p.inputsKnown,
+		unknown:    p.setUnknownVersion,
+		initialise: p.initialise,
+		advance:    p.advance,
+	}
+}
+
+func (p PackageVersionResource) inputsKnown(data packageVersionModelV0) bool {
+	return !data.UpstreamVersion.IsUnknown() && !data.RevisionSuffix.IsUnknown() && mapFullyKnown(data.EpochTriggers) &&
+		mapFullyKnown(data.RevisionTriggers) && !data.EpochInitialValue.IsUnknown() && !data.RevisionInitialValue.IsUnknown() &&
+		!data.MaxHistory.IsUnknown()
+}
+
+// setUnknownVersion plans the version while the inputs are unknown. A new resource still starts at the initial epoch
+// and revision, which are unknown themselves until configured from known values.
+func (p PackageVersionResource) setUnknownVersion(data *packageVersionModelV0, creation bool) {
+	if creation {
+		data.EpochValue = data.EpochInitialValue
+		data.RevisionValue = data.RevisionInitialValue
+	} else {
+		data.EpochValue = types.Int64Unknown()
+		data.RevisionValue = types.Int64Unknown()
+	}
+	data.Value = types.StringUnknown()
+	data.FullRevision = types.StringUnknown()
+	data.History = types.ListUnknown(packageVersionHistoryEntryType)
+}
+
+// initialise sets the version and history of a resource which is being created.
+func (p PackageVersionResource) initialise(ctx context.Context, data *packageVersionModelV0) diag.Diagnostics {
+	p.setVersion(data, data.EpochInitialValue.ValueInt64(), data.RevisionInitialValue.ValueInt64())
+	data.History = appendAndTruncate(ctx, types.ListNull(packageVersionHistoryEntryType), p.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return nil
+}
+
+// advance sets the version and history from the prior state. A change to the epoch triggers increments the epoch, a
+// change to the upstream version starts the revision over, and otherwise a change to the revision triggers increments
+// the revision. The new version must sort after the current one.
+func (p PackageVersionResource) advance(ctx context.Context, prior packageVersionModelV0, data *packageVersionModelV0, imported bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data.Value = prior.Value
+	data.EpochValue = prior.EpochValue
+	data.RevisionValue = prior.RevisionValue
+	data.FullRevision = prior.FullRevision
+	data.History = prior.History
+
+	if imported {
+		// The upstream version was taken from the import identifier, so a different configured one is still a new
+		// version.
+		prior.EpochTriggers = data.EpochTriggers
+		prior.RevisionTriggers = data.RevisionTriggers
+		if prior.UpstreamVersion.Equal(data.UpstreamVersion) {
+			data.History = replaceLastAndTruncate(ctx, prior.History, p.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+			return diags
+		}
+	}
+
+	epoch, revision := prior.EpochValue.ValueInt64(), prior.RevisionValue.ValueInt64()
+	switch {
+	case !prior.EpochTriggers.Equal(data.EpochTriggers):
+		epoch, revision = epoch+1, data.RevisionInitialValue.ValueInt64()
+	case !prior.UpstreamVersion.Equal(data.UpstreamVersion):
+		revision = data.RevisionInitialValue.ValueInt64()
+	case !prior.RevisionTriggers.Equal(data.RevisionTriggers):
+		revision++
+	default:
+		return diags
+	}
+
+	p.setVersion(data, epoch, revision)
+	current := packageVersion{epoch: prior.EpochValue.ValueInt64(), upstream: prior.UpstreamVersion.ValueString(), revision: prior.FullRevision.ValueString()}
+	next := packageVersion{epoch: epoch, upstream: data.UpstreamVersion.ValueString(), revision: data.FullRevision.ValueString()}
+	if next.compare(current, data.Format.ValueString()) <= 0 {
+		diags.AddAttributeError(
+			path.Root("upstream_version"),
+			"Version Would Go Backwards",
+			fmt.Sprintf("The version %s doesn't sort after the current version %s. Change epoch_triggers at the same time to start a new epoch.", next, current),
+		)
+		return diags
+	}
+
+	data.History = appendAndTruncate(ctx, prior.History, p.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return diags
+}
+
+func (p PackageVersionResource) setVersion(data *packageVersionModelV0, epoch int64, revision int64) {
+	fullRevision := strconv.FormatInt(revision, 10) + data.RevisionSuffix.ValueString()
+	version := packageVersion{epoch: epoch, upstream: data.UpstreamVersion.ValueString(), revision: fullRevision}
+	data.Value = types.StringValue(version.String())
+	data.EpochValue = types.Int64Value(epoch)
+	data.RevisionValue = types.Int64Value(revision)
+	data.FullRevision = types.StringValue(fullRevision)
+}
+
+func (p PackageVersionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := parseImportId(req.ID, "value", "value", "format", "max_history")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: %s. Expected a version such as `1:2.4.1-3`, or `value=2.4.1-3.el9,format=rpm`.", req.ID, err))
+		return
+	}
+
+	data := packageVersionModelV0{
+		Id:                   types.StringValue(uuid.New().String()),
+		MaxHistory:           types.Int64Value(defaultMaxHistory),
+		Format:               types.StringValue("deb"),
+		RevisionSuffix:       types.StringNull(),
+		EpochInitialValue:    types.Int64Value(0),
+		RevisionInitialValue: types.Int64Value(1),
+		EpochTriggers:        types.MapNull(types.StringType),
+		RevisionTriggers:     types.MapNull(types.StringType),
+	}
+	if format, ok := values["format"]; ok {
+		if format != "deb" && format != "rpm" {
+			resp.Diagnostics.AddAttributeError(path.Root("format"), "Invalid Import Identifier", fmt.Sprintf("The format %q must be `deb` or `rpm`.", format))
+		}
+		data.Format = types.StringValue(format)
+	}
+	if raw, ok := values["max_history"]; ok {
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || number < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_history"), "Invalid Import Identifier", fmt.Sprintf("The value %q for max_history must be a whole number of at least 1.", raw))
+		}
+		data.MaxHistory = types.Int64Value(number)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version, revision, suffix, err := parsePackageVersion(values["value"], data.Format.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid Import Identifier", fmt.Sprintf("The version %s.", err))
+		return
+	}
+	data.UpstreamVersion = types.StringValue(version.upstream)
+	if suffix != "" {
+		data.RevisionSuffix = types.StringValue(suffix)
+	}
+	p.setVersion(&data, version.epoch, revision)
+	data.History = appendAndTruncate(ctx, types.ListNull(packageVersionHistoryEntryType), p.createHistoryEntry(data), data.MaxHistory.ValueInt64())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
+}
+
+var packageVersionHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":             types.StringType,
+		"upstream_version":  types.StringType,
+		"epoch_triggers":    types.MapType{ElemType: types.StringType},
+		"revision_triggers": types.MapType{ElemType: types.StringType},
+	},
+}
+
+func (p PackageVersionResource) createHistoryEntry(data packageVersionModelV0) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		packageVersionHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"value":             data.Value,
+			"upstream_version":  data.UpstreamVersion,
+			"epoch_triggers":    data.EpochTriggers,
+			"revision_triggers": data.RevisionTriggers,
+		},
+	)
+}
+
+type packageVersionModelV0 struct {
+	Id                   types.String `tfsdk:"id"`
+	Value                types.String `tfsdk:"value"`
+	EpochValue           types.Int64  `tfsdk:"epoch_value"`
+	RevisionValue        types.Int64  `tfsdk:"revision_value"`
+	FullRevision         types.String `tfsdk:"full_revision"`
+	MaxHistory           types.Int64  `tfsdk:"max_history"`
+	History              types.List   `tfsdk:"history"`
+	Format               types.String `tfsdk:"format"`
+	UpstreamVersion      types.String `tfsdk:"upstream_version"`
+	RevisionSuffix       types.String `tfsdk:"revision_suffix"`
+	EpochInitialValue    types.Int64  `tfsdk:"epoch_initial_value"`
+	RevisionInitialValue types.Int64  `tfsdk:"revision_initial_value"`
+	EpochTriggers        types.Map    `tfsdk:"epoch_triggers"`
+	RevisionTriggers     types.Map    `tfsdk:"revision_triggers"`
+}
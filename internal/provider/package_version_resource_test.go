@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"regexp"
+	"testing"
+)
+
+func packageVersionStep(upstream string, revision string, epoch string) string {
+	return `
+		resource counter_package_version this {
+			upstream_version = "` + upstream + `"
+			revision_triggers = {
+				hash = "` + revision + `"
+			}
+			epoch_triggers = {
+				hash = "` + epoch + `"
+			}
+		}
+	`
+}
+
+func TestAccPackageVersionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: packageVersionStep("2.4.1", "potatoes", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_package_version.this", "value", "2.4.1-1"),
+					resource.TestCheckResourceAttr("counter_package_version.this", "epoch_value", "0"),
+					resource.TestCheckResourceAttr("counter_package_version.this", "revision_value", "1"),
+				),
+			},
+			// Packaging-only changes increment the revision
+			{
+				Config: packageVersionStep("2.4.1", "eggs", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_package_version.this", "value", "2.4.1-2"),
+				),
+			},
+			// A new upstream version starts the revision over
+			{
+				Config: packageVersionStep("2.5.0~rc1", "eggs", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_package_version.this", "value", "2.5.0~rc1-1"),
+					resource.TestCheckResourceAttr("counter_package_version.this", "history.#", "3"),
+					resource.TestCheckResourceAttr("counter_package_version.this", "history.2.upstream_version", "2.5.0~rc1"),
+				),
+			},
+			// The final release sorts after its release candidate
+			{
+				Config: packageVersionStep("2.5.0", "eggs", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_package_version.this", "value", "2.5.0-1"),
+				),
+			},
+			// Going back to an older upstream version needs a new epoch
+			{
+				Config:      packageVersionStep("2.4.9", "eggs", "potatoes"),
+				ExpectError: regexp.MustCompile(`doesn't sort after the current version 2.5.0-1`),
+			},
+			{
+				Config: packageVersionStep("2.4.9", "eggs", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_package_version.this", "value", "1:2.4.9-1"),
+					resource.TestCheckResourceAttr("counter_package_version.this", "epoch_value", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPackageVersionResourceRPM(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource counter_package_version this {
+						format           = "rpm"
+						upstream_version = "1.0^git20240101"
+						revision_suffix  = ".el9"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_package_version.this", "value", "1.0^git20240101-1.el9"),
+					resource.TestCheckResourceAttr("counter_package_version.this", "full_revision", "1.el9"),
+				),
+			},
+			// RPM versions can't contain hyphens
+			{
+				Config: `
+					resource counter_package_version this {
+						format           = "rpm"
+						upstream_version = "1.0-beta"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Upstream Version`),
+			},
+		},
+	})
+}
+
+func TestAccPackageVersionResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: packageVersionStep("2.4.1", "potatoes", "potatoes"),
+			},
+			{
+				Config:             packageVersionStep("2.4.1", "potatoes", "potatoes"),
+				ResourceName:       "counter_package_version.this",
+				ImportState:        true,
+				ImportStateId:      "2:2.4.1-7",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without incrementing
+			{
+				Config: packageVersionStep("2.4.1", "potatoes", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_package_version.this", "value", "2:2.4.1-7"),
+					resource.TestCheckResourceAttr("counter_package_version.this", "history.#", "1"),
+					resource.TestCheckResourceAttr("counter_package_version.this", "history.0.revision_triggers.hash", "potatoes"),
+				),
+			},
+			{
+				Config: packageVersionStep("2.4.1", "eggs", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_package_version.this", "value", "2:2.4.1-8"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPackageVersionResourceUnknownInitialValue(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The initial epoch isn't known until the source is created
+			{
+				Config: `
+					resource terraform_data source {
+						input = 3
+					}
+
+					resource counter_package_version this {
+						upstream_version    = "1.0"
+						epoch_initial_value = terraform_data.source.output
+						revision_triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("counter_package_version.this", tfjsonpath.New("value")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_package_version.this", "value", "3:1.0-1"),
+					resource.TestCheckResourceAttr("counter_package_version.this", "epoch_value", "3"),
+				),
+			},
+		},
+	})
+}
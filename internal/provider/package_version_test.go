@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestComparePackageVersionParts(t *testing.T) {
+	for _, test := range []struct {
+		format string
+		a      string
+		b      string
+		want   int
+	}{
+		{"deb", "1.0~rc1", "1.0", -1},
+		{"deb", "1.0~~", "1.0~", -1},
+		{"deb", "1.0a", "1.0", 1},
+		{"deb", "1.0+a", "1.0a", 1},
+		{"deb", "1.10", "1.9", 1},
+		{"deb", "1.01", "1.1", 0},
+		{"deb", "1.0", "1.0.0", -1},
+		{"deb", "1~bpo12+1", "1", -1},
+		{"rpm", "1.0~rc1", "1.0", -1},
+		{"rpm", "1.0^git1", "1.0", 1},
+		{"rpm", "1.0^git1", "1.0.1", -1},
+		{"rpm", "1.10", "1.9", 1},
+		{"rpm", "1.a", "1.1", -1},
+		{"rpm", "1_0", "1.0", 0},
+		{"rpm", "2.el9", "1.el9", 1},
+	} {
+		compare := compareDebianVersionPart
+		if test.format == "rpm" {
+			compare = compareRPMVersionPart
+		}
+		if got := compare(test.a, test.b); got != test.want {
+			t.Errorf("comparing %s versions %q and %q: expected %d, got %d", test.format, test.a, test.b, test.want, got)
+		}
+		if got := compare(test.b, test.a); got != -test.want {
+			t.Errorf("comparing %s versions %q and %q: expected %d, got %d", test.format, test.b, test.a, -test.want, got)
+		}
+	}
+}
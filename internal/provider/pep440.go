@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// pep440VersionPattern matches a public PEP 440 version, including the alternative spellings which normalise to the
+// canonical form, see https://packaging.python.org/en/latest/specifications/version-specifiers/#appendix-parsing-version-strings-with-regular-expressions.
+var pep440VersionPattern = regexp.MustCompile(`(?i)^v?(?:([0-9]+)!)?([0-9]+(?:\.[0-9]+)*)(?:[-_.]?(alpha|beta|preview|pre|a|b|c|rc)[-_.]?([0-9]+)?)?(?:-([0-9]+)|[-_.]?(post|rev|r)[-_.]?([0-9]+)?)?(?:[-_.]?(dev)[-_.]?([0-9]+)?)?$`)
+
+// pep440PrePhases are the pre-release phases in ascending order.
+var pep440PrePhases = []string{"a", "b", "rc"}
+
+// pep440PrePhaseSpellings normalises the alternative spellings of pre-release phases.
+var pep440PrePhaseSpellings = map[string]string{
+	"a":       "a",
+	"alpha":   "a",
+	"b":       "b",
+	"beta":    "b",
+	"c":       "rc",
+	"rc":      "rc",
+	"pre":     "rc",
+	"preview": "rc",
+}
+
+type pep440Version struct {
+	epoch   int64
+	release []int64
+	// prePhase is one of pep440PrePhases, or "" when the version isn't a pre-release.
+	prePhase string
+	pre      int64
+	post     int64
+	hasPost  bool
+	dev      int64
+	hasDev   bool
+}
+
+func parsePEP440Version(value string) (pep440Version, error) {
+	matches := pep440VersionPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return pep440Version{}, fmt.Errorf("%q is not a valid PEP 440 version", value)
+	}
+
+	var err error
+	number := func(match string) int64 {
+		if match == "" || err != nil {
+			return 0
+		}
+		parsed, parseErr := strconv.ParseInt(match, 10, 64)
+		if parseErr != nil {
+			err = fmt.Errorf("%q is not a valid PEP 440 version: %w", value, parseErr)
+		}
+		return parsed
+	}
+
+	version := pep440Version{epoch: number(matches[1])}
+	for _, segment := range strings.Split(matches[2], ".") {
+		version.release = append(version.release, number(segment))
+	}
+	if matches[3] != "" {
+		version.prePhase = pep440PrePhaseSpellings[strings.ToLower(matches[3])]
+		version.pre = number(matches[4])
+	}
+	if matches[5] != "" || matches[6] != "" {
+		version.hasPost = true
+		version.post = number(matches[5] + matches[7])
+	}
+	if matches[8] != "" {
+		version.hasDev = true
+		version.dev = number(matches[9])
+	}
+	if err != nil {
+		return pep440Version{}, err
+	}
+	return version, nil
+}
+
+// String returns the normalised form of the version.
+func (v pep440Version) String() string {
+	var value strings.Builder
+	if v.epoch != 0 {
+		fmt.Fprintf(&value, "%d!", v.epoch)
+	}
+	for i, segment := range v.release {
+		if i > 0 {
+			value.WriteString(".")
+		}
+		value.WriteString(strconv.FormatInt(segment, 10))
+	}
+	value.WriteString(v.preValue())
+	if v.hasPost {
+		fmt.Fprintf(&value, ".post%d", v.post)
+	}
+	if v.hasDev {
+		fmt.Fprintf(&value, ".dev%d", v.dev)
+	}
+	return value.String()
+}
+
+// preValue returns the pre-release segment, such as `rc2`, or "" when the version isn't a pre-release.
+func (v pep440Version) preValue() string {
+	if v.prePhase == "" {
+		return ""
+	}
+	return v.prePhase + strconv.FormatInt(v.pre, 10)
+}
+
+// compare returns -1, 0 or 1 according to the PEP 440 ordering of the versions.
+func (v pep440Version) compare(other pep440Version) int {
+	if result := cmp.Compare(v.epoch, other.epoch); result != 0 {
+		return result
+	}
+
+	// Release segments are compared as if padded with zeros to the same length.
+	for i := 0; i < len(v.release) || i < len(other.release); i++ {
+		var a, b int64
+		if i < len(v.release) {
+			a = v.release[i]
+		}
+		if i < len(other.release) {
+			b = other.release[i]
+		}
+		if result := cmp.Compare(a, b); result != 0 {
+			return result
+		}
+	}
+
+	a, b := v.suffixKey(), other.suffixKey()
+	for i := range a {
+		if result := cmp.Compare(a[i], b[i]); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// suffixKey orders the pre, post and dev segments of versions with the same release. A developmental release of a
+// final release sorts before its pre-releases, a version sorts before its post-releases, and a developmental release
+// sorts before the version it is the development of.
+func (v pep440Version) suffixKey() [4]int64 {
+	key := [4]int64{int64(len(pep440PrePhases)), 0, -1, math.MaxInt64}
+	switch {
+	case v.prePhase != "":
+		key[0], key[1] = int64(slices.Index(pep440PrePhases, v.prePhase)), v.pre
+	case !v.hasPost && v.hasDev:
+		key[0] = -1
+	}
+	if v.hasPost {
+		key[2] = v.post
+	}
+	if v.hasDev {
+		key[3] = v.dev
+	}
+	return key
+}
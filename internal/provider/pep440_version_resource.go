@@ -0,0 +1,605 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"strconv"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PEP440VersionResource{}
+var _ resource.ResourceWithModifyPlan = &PEP440VersionResource{}
+var _ resource.ResourceWithImportState = &PEP440VersionResource{}
+var _ resource.ResourceWithConfigValidators = &PEP440VersionResource{}
+
+func NewPEP440VersionResource() resource.Resource {
+	return &PEP440VersionResource{}
+}
+
+type PEP440VersionResource struct {
+}
+
+func (p PEP440VersionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pep440_version"
+}
+
+func (p PEP440VersionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A [PEP 440](https://peps.python.org/pep-0440/) version for Python packages, such as `1.4.0rc2`, `1.4.0.post1` or `1!2.0.0.dev7`, whose segments increment according to the configured triggers.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The version in normalised `[<epoch>!]<major>.<minor>.<micro>[<pre>][.post<N>][.dev<N>]` form.",
+			},
+			"epoch_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current epoch, which is only included in `value` when it isn't 0.",
+			},
+			"major_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current major release segment.",
+			},
+			"minor_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current minor release segment.",
+			},
+			"micro_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current micro release segment.",
+			},
+			"pre_value": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The pre-release segment, such as `rc2`, or null when the version isn't a pre-release.",
+			},
+			"post_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The post-release number, or null when the version isn't a post-release.",
+			},
+			"dev_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The developmental release number, or null when the version isn't a developmental release.",
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of versions this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"history": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "A list of versions that this resource has produced, in ascending PEP 440 order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+						"epoch_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"major_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"minor_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"micro_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"pre_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"post_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"dev_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"epoch_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The initial epoch. Must not be negative. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"major_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "The initial major release segment. Must not be negative. Defaults to 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"minor_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The initial minor release segment. Must not be negative. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"micro_initial_value": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The initial micro release segment. Must not be negative. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"epoch_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the epoch to increment when any of the values change, starting the release segments over at their initial values. Use this when the versioning scheme changes and new versions would otherwise sort before old ones.",
+			},
+			"major_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the major release segment to increment when any of the values change.",
+			},
+			"minor_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the minor release segment to increment when any of the values change.",
+			},
+			"micro_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the micro release segment to increment when any of the values change.",
+			},
+			"pre_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the pre-release number to increment when any of the values change while the version is a pre-release.",
+			},
+			"post_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will produce the next post-release, such as `1.4.0.post1`, when any of the values change.",
+			},
+			"dev_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the developmental release number to increment when any of the values change while `development` is enabled.",
+			},
+			"pre_phase": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The pre-release phase, one of `a`, `b` and `rc`. Versions start at `<phase>1` and move to a later phase or, when this is removed, to the final release. A final release entering a phase without another change becomes a pre-release of the next micro version. Moving back to an earlier phase of the same release is an error.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(pep440PrePhases...),
+				},
+			},
+			"development": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Produce developmental releases, such as `1.4.1.dev1`, which sort before the version they are the development of. Enabling this without another change starts development of the next version, and disabling it releases the version being developed. Defaults to false.",
+			},
+		},
+	}
+}
+
+func (p PEP440VersionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		triggersConfigured("epoch_triggers", "major_triggers", "minor_triggers", "micro_triggers", "pre_triggers", "post_triggers", "dev_triggers"),
+	}
+}
+
+func (p PEP440VersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data pep440VersionModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	resp.Diagnostics.Append(p.initialise(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (p PEP440VersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (p PEP440VersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	p.lifecycle().update(ctx, req, resp)
+}
+
+func (p PEP440VersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (p PEP440VersionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	p.lifecycle().modifyPlan(ctx, req, resp)
+}
+
+func (p PEP440VersionResource) lifecycle() triggeredLifecycle[pep440VersionModelV0] {
+	return triggeredLifecycle[pep440VersionModelV0]{
+		known:      func(data pep440VersionModelV0) bool { return !p.hasUnknownInputs(data) },
+		unknown:    p.setUnknownInputsVersion,
+		initialise: p.initialise,
+		advance:    p.advance,
+	}
+}
+
+// setUnknownInputsVersion plans the version of a new resource, which starts at the initial version whatever the
+// triggers are, once the initial values, pre-release phase and development state are known.
+func (p PEP440VersionResource) setUnknownInputsVersion(data *pep440VersionModelV0, creation bool) {
+	if creation && p.initialVersionKnown(*data) {
+		p.setVersion(data, p.initialVersion(*data))
+	} else {
+		p.setUnknownVersion(data)
+	}
+	data.History = types.ListUnknown(pep440VersionHistoryEntryType)
+}
+
+func (p PEP440VersionResource) hasUnknownInputs(data pep440VersionModelV0) bool {
+	return !mapFullyKnown(data.EpochTriggers) || !mapFullyKnown(data.MajorTriggers) || !mapFullyKnown(data.MinorTriggers) ||
+		!mapFullyKnown(data.MicroTriggers) || !mapFullyKnown(data.PreTriggers) || !mapFullyKnown(data.PostTriggers) ||
+		!mapFullyKnown(data.DevTriggers) || data.MaxHistory.IsUnknown() || !p.initialVersionKnown(data)
+}
+
+func (p PEP440VersionResource) initialVersionKnown(data pep440VersionModelV0) bool {
+	return !data.EpochInitialValue.IsUnknown() && !data.MajorInitialValue.IsUnknown() && !data.MinorInitialValue.IsUnknown() &&
+		!data.MicroInitialValue.IsUnknown() && !data.PrePhase.IsUnknown() && !data.Development.IsUnknown()
+}
+
+func (p PEP440VersionResource) initialVersion(data pep440VersionModelV0) pep440Version {
+	return p.start(data, pep440Version{
+		epoch:   data.EpochInitialValue.ValueInt64(),
+		release: p.initialRelease(data),
+	})
+}
+
+func (p PEP440VersionResource) initialRelease(data pep440VersionModelV0) []int64 {
+	return []int64{data.MajorInitialValue.ValueInt64(), data.MinorInitialValue.ValueInt64(), data.MicroInitialValue.ValueInt64()}
+}
+
+// start returns a new version of the given release in the configured pre-release phase and development state.
+func (p PEP440VersionResource) start(data pep440VersionModelV0, v pep440Version) pep440Version {
+	next := pep440Version{epoch: v.epoch, release: v.release}
+	if phase := data.PrePhase.ValueString(); phase != "" {
+		next.prePhase, next.pre = phase, 1
+	}
+	if data.Development.ValueBool() {
+		next.hasDev, next.dev = true, 1
+	}
+	return next
+}
+
+// initialise sets the version and history of a resource which is being created.
+func (p PEP440VersionResource) initialise(ctx context.Context, data *pep440VersionModelV0) diag.Diagnostics {
+	p.setVersion(data, p.initialVersion(*data))
+	data.History = appendAndTruncate(ctx, types.ListNull(pep440VersionHistoryEntryType), p.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return nil
+}
+
+// advance sets the version and history from the prior state according to the triggers, pre-release phase and
+// development state which changed. It is used both while planning and, when the triggers were unknown during
+// planning, while applying.
+func (p PEP440VersionResource) advance(ctx context.Context, prior pep440VersionModelV0, data *pep440VersionModelV0, imported bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	current, err := parsePEP440Version(prior.Value.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Resource State", fmt.Sprintf("Unable to parse the current version: %s.", err))
+		return diags
+	}
+	p.setVersion(data, current)
+	data.History = prior.History
+
+	if imported {
+		data.History = replaceLastAndTruncate(ctx, prior.History, p.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+		return diags
+	}
+
+	next, changed := p.next(prior, *data, current)
+	if !changed {
+		return diags
+	}
+	if next.compare(current) <= 0 {
+		diags.AddError(
+			"Version Would Go Backwards",
+			fmt.Sprintf("The configuration would change the version from %s to %s, which sorts before it under PEP 440. Pre-release phases can only move from `a` to `b` to `rc`, and a pre-release can't become a developmental release of its own final release.", current, next),
+		)
+		return diags
+	}
+
+	p.setVersion(data, next)
+	data.History = appendAndTruncate(ctx, prior.History, p.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return diags
+}
+
+// next returns the version for the configured triggers, pre-release phase and development state, and whether it
+// changed. Release segment changes take precedence over phase changes, which take precedence over pre, post and dev
+// triggers. Only the highest changed release segment increments, and the lower ones start over at 0.
+func (p PEP440VersionResource) next(prior pep440VersionModelV0, data pep440VersionModelV0, current pep440Version) (pep440Version, bool) {
+	phase := data.PrePhase.ValueString()
+	development := data.Development.ValueBool()
+	release := func(segment int) pep440Version {
+		next := pep440Version{epoch: current.epoch, release: []int64{current.release[0], current.release[1], current.release[2]}}
+		next.release[segment]++
+		for i := segment + 1; i < len(next.release); i++ {
+			next.release[i] = 0
+		}
+		return p.start(data, next)
+	}
+	withDev := func(next pep440Version) pep440Version {
+		next.hasDev, next.dev = development, 0
+		if development {
+			next.dev = 1
+		}
+		return next
+	}
+
+	switch {
+	case !prior.EpochTriggers.Equal(data.EpochTriggers):
+		return p.start(data, pep440Version{epoch: current.epoch + 1, release: p.initialRelease(data)}), true
+	case !prior.MajorTriggers.Equal(data.MajorTriggers):
+		return release(0), true
+	case !prior.MinorTriggers.Equal(data.MinorTriggers):
+		return release(1), true
+	case !prior.MicroTriggers.Equal(data.MicroTriggers):
+		return release(2), true
+	case phase != current.prePhase && phase == "":
+		// Promote the pre-release to the final release.
+		return withDev(pep440Version{epoch: current.epoch, release: current.release}), true
+	case phase != current.prePhase && current.prePhase == "" && (current.hasPost || !current.hasDev):
+		// A final release can't have pre-releases of its own, so enter the phase for the next micro version.
+		return release(2), true
+	case phase != current.prePhase:
+		// Developmental releases of a final release sort before its pre-releases, so they can enter a phase directly.
+		return p.start(data, current), true
+	case phase != "" && !prior.PreTriggers.Equal(data.PreTriggers):
+		return withDev(pep440Version{epoch: current.epoch, release: current.release, prePhase: current.prePhase, pre: current.pre + 1}), true
+	case !prior.PostTriggers.Equal(data.PostTriggers):
+		next := current
+		next.post, next.hasPost = 1, true
+		if current.hasPost {
+			next.post = current.post + 1
+		}
+		return withDev(next), true
+	case development && !current.hasDev:
+		// Start developing the next version at the lowest segment of the current one.
+		next := current
+		switch {
+		case current.hasPost:
+			next.post++
+		case current.prePhase != "":
+			next.pre++
+		default:
+			next = release(2)
+		}
+		return withDev(next), true
+	case development && !prior.DevTriggers.Equal(data.DevTriggers):
+		next := current
+		next.dev++
+		return next, true
+	case !development && current.hasDev:
+		// Release the version being developed.
+		next := current
+		next.hasDev, next.dev = false, 0
+		return next, true
+	}
+	return current, false
+}
+
+func (p PEP440VersionResource) setVersion(data *pep440VersionModelV0, version pep440Version) {
+	data.Value = types.StringValue(version.String())
+	data.EpochValue = types.Int64Value(version.epoch)
+	data.MajorValue = types.Int64Value(version.release[0])
+	data.MinorValue = types.Int64Value(version.release[1])
+	data.MicroValue = types.Int64Value(version.release[2])
+	data.PreValue = types.StringNull()
+	if version.prePhase != "" {
+		data.PreValue = types.StringValue(version.preValue())
+	}
+	data.PostValue = types.Int64Null()
+	if version.hasPost {
+		data.PostValue = types.Int64Value(version.post)
+	}
+	data.DevValue = types.Int64Null()
+	if version.hasDev {
+		data.DevValue = types.Int64Value(version.dev)
+	}
+}
+
+func (p PEP440VersionResource) setUnknownVersion(data *pep440VersionModelV0) {
+	data.Value = types.StringUnknown()
+	data.EpochValue = types.Int64Unknown()
+	data.MajorValue = types.Int64Unknown()
+	data.MinorValue = types.Int64Unknown()
+	data.MicroValue = types.Int64Unknown()
+	data.PreValue = types.StringUnknown()
+	data.PostValue = types.Int64Unknown()
+	data.DevValue = types.Int64Unknown()
+}
+
+func (p PEP440VersionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := parseImportId(req.ID, "value", "value", "epoch_initial_value", "major_initial_value", "minor_initial_value", "micro_initial_value", "max_history")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: %s. Expected a version such as `1.4.0rc2`, or `value=1.4.0rc2,major_initial_value=0`.", req.ID, err))
+		return
+	}
+
+	data := pep440VersionModelV0{
+		Id:                types.StringValue(uuid.New().String()),
+		MaxHistory:        types.Int64Value(defaultMaxHistory),
+		EpochInitialValue: types.Int64Value(0),
+		MajorInitialValue: types.Int64Value(1),
+		MinorInitialValue: types.Int64Value(0),
+		MicroInitialValue: types.Int64Value(0),
+		EpochTriggers:     types.MapNull(types.StringType),
+		MajorTriggers:     types.MapNull(types.StringType),
+		MinorTriggers:     types.MapNull(types.StringType),
+		MicroTriggers:     types.MapNull(types.StringType),
+		PreTriggers:       types.MapNull(types.StringType),
+		PostTriggers:      types.MapNull(types.StringType),
+		DevTriggers:       types.MapNull(types.StringType),
+		PrePhase:          types.StringNull(),
+		Development:       types.BoolValue(false),
+	}
+	for attribute, raw := range values {
+		if attribute == "value" {
+			version, err := parsePEP440Version(raw)
+			if err != nil || len(version.release) > 3 {
+				resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Import Identifier", fmt.Sprintf("The version %q is not a PEP 440 version with at most three release segments.", raw))
+				continue
+			}
+			// Missing release segments are equivalent to 0.
+			for len(version.release) < 3 {
+				version.release = append(version.release, 0)
+			}
+			p.setVersion(&data, version)
+			if version.prePhase != "" {
+				data.PrePhase = types.StringValue(version.prePhase)
+			}
+			data.Development = types.BoolValue(version.hasDev)
+			continue
+		}
+
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Import Identifier", fmt.Sprintf("The value %q for %s is not a whole number.", raw, attribute))
+			continue
+		}
+		if number < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Import Identifier", fmt.Sprintf("The value %q for %s must not be negative.", raw, attribute))
+			continue
+		}
+		switch attribute {
+		case "epoch_initial_value":
+			data.EpochInitialValue = types.Int64Value(number)
+		case "major_initial_value":
+			data.MajorInitialValue = types.Int64Value(number)
+		case "minor_initial_value":
+			data.MinorInitialValue = types.Int64Value(number)
+		case "micro_initial_value":
+			data.MicroInitialValue = types.Int64Value(number)
+		case "max_history":
+			data.MaxHistory = types.Int64Value(number)
+		}
+	}
+	if data.MaxHistory.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_history"), "Invalid Import Identifier", "The max_history must be at least 1.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.History = appendAndTruncate(ctx, types.ListNull(pep440VersionHistoryEntryType), p.createHistoryEntry(data), data.MaxHistory.ValueInt64())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
+}
+
+var pep440VersionHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":          types.StringType,
+		"epoch_triggers": types.MapType{ElemType: types.StringType},
+		"major_triggers": types.MapType{ElemType: types.StringType},
+		"minor_triggers": types.MapType{ElemType: types.StringType},
+		"micro_triggers": types.MapType{ElemType: types.StringType},
+		"pre_triggers":   types.MapType{ElemType: types.StringType},
+		"post_triggers":  types.MapType{ElemType: types.StringType},
+		"dev_triggers":   types.MapType{ElemType: types.StringType},
+	},
+}
+
+func (p PEP440VersionResource) createHistoryEntry(data pep440VersionModelV0) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		pep440VersionHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"value":          data.Value,
+			"epoch_triggers": data.EpochTriggers,
+			"major_triggers": data.MajorTriggers,
+			"minor_triggers": data.MinorTriggers,
+			"micro_triggers": data.MicroTriggers,
+			"pre_triggers":   data.PreTriggers,
+			"post_triggers":  data.PostTriggers,
+			"dev_triggers":   data.DevTriggers,
+		},
+	)
+}
+
+type pep440VersionModelV0 struct {
+	Id                types.String `tfsdk:"id"`
+	Value             types.String `tfsdk:"value"`
+	EpochValue        types.Int64  `tfsdk:"epoch_value"`
+	MajorValue        types.Int64  `tfsdk:"major_value"`
+	MinorValue        types.Int64  `tfsdk:"minor_value"`
+	MicroValue        types.Int64  `tfsdk:"micro_value"`
+	PreValue          types.String `tfsdk:"pre_value"`
+	PostValue         types.Int64  `tfsdk:"post_value"`
+	DevValue          types.Int64  `tfsdk:"dev_value"`
+	MaxHistory        types.Int64  `tfsdk:"max_history"`
+	History           types.List   `tfsdk:"history"`
+	EpochInitialValue types.Int64  `tfsdk:"epoch_initial_value"`
+	MajorInitialValue types.Int64  `tfsdk:"major_initial_value"`
+	MinorInitialValue types.Int64  `tfsdk:"minor_initial_value"`
+	MicroInitialValue types.Int64  `tfsdk:"micro_initial_value"`
+	EpochTriggers     types.Map    `tfsdk:"epoch_triggers"`
+	MajorTriggers     types.Map    `tfsdk:"major_triggers"`
+	MinorTriggers     types.Map    `tfsdk:"minor_triggers"`
+	MicroTriggers     types.Map    `tfsdk:"micro_triggers"`
+	PreTriggers       types.Map    `tfsdk:"pre_triggers"`
+	PostTriggers      types.Map    `tfsdk:"post_triggers"`
+	DevTriggers       types.Map    `tfsdk:"dev_triggers"`
+	PrePhase          types.String `tfsdk:"pre_phase"`
+	Development       types.Bool   `tfsdk:"development"`
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"regexp"
+	"testing"
+)
+
+func pep440Step(minor string, pre string, post string, phase string, development bool) string {
+	config := `
+		resource counter_pep440_version this {
+			minor_triggers = {
+				hash = "` + minor + `"
+			}
+			pre_triggers = {
+				hash = "` + pre + `"
+			}
+			post_triggers = {
+				hash = "` + post + `"
+			}
+	`
+	if phase != "" {
+		config += `
+			pre_phase = "` + phase + `"
+		`
+	}
+	if development {
+		config += `
+			development = true
+		`
+	}
+	return config + `
+		}
+	`
+}
+
+func TestAccPEP440VersionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: pep440Step("potatoes", "a", "a", "", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "1.0.0"),
+					resource.TestCheckNoResourceAttr("counter_pep440_version.this", "pre_value"),
+				),
+			},
+			// Starting development of the next minor version
+			{
+				Config: pep440Step("eggs", "a", "a", "", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "1.1.0.dev1"),
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "dev_value", "1"),
+				),
+			},
+			// Developmental releases sort before the pre-releases of the same version
+			{
+				Config: pep440Step("eggs", "a", "a", "rc", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "1.1.0rc1"),
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "pre_value", "rc1"),
+					resource.TestCheckNoResourceAttr("counter_pep440_version.this", "dev_value"),
+				),
+			},
+			{
+				Config: pep440Step("eggs", "b", "a", "rc", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "1.1.0rc2"),
+				),
+			},
+			// Removing the phase promotes the version to the final release
+			{
+				Config: pep440Step("eggs", "b", "a", "", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "1.1.0"),
+				),
+			},
+			{
+				Config: pep440Step("eggs", "b", "b", "", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "1.1.0.post1"),
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "post_value", "1"),
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "history.#", "6"),
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "history.4.value", "1.1.0"),
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "history.5.value", "1.1.0.post1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPEP440VersionResourceEpoch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource counter_pep440_version this {
+						major_initial_value = 2024
+						epoch_triggers = {
+							scheme = "calendar"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "2024.0.0"),
+				),
+			},
+			// A new epoch sorts after every version of the previous one
+			{
+				Config: `
+					resource counter_pep440_version this {
+						major_initial_value = 2024
+						epoch_triggers = {
+							scheme = "semantic"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "1!2024.0.0"),
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "epoch_value", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPEP440VersionResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: pep440Step("potatoes", "a", "a", "rc", false),
+			},
+			// Import an existing version, which is normalised
+			{
+				Config:             pep440Step("potatoes", "a", "a", "rc", false),
+				ResourceName:       "counter_pep440_version.this",
+				ImportState:        true,
+				ImportStateId:      "v2.3-c4",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without incrementing
+			{
+				Config: pep440Step("potatoes", "a", "a", "rc", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "2.3.0rc4"),
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "history.#", "1"),
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "history.0.minor_triggers.hash", "potatoes"),
+				),
+			},
+			{
+				Config: pep440Step("potatoes", "b", "a", "rc", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "2.3.0rc5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPEP440VersionResourceValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      pep440Step("potatoes", "a", "a", "beta", false),
+				ExpectError: regexp.MustCompile(`pre_phase value must be one of`),
+			},
+			{
+				Config: pep440Step("potatoes", "a", "a", "rc", false),
+			},
+			{
+				Config:      pep440Step("potatoes", "a", "a", "b", false),
+				ExpectError: regexp.MustCompile(`Version Would Go Backwards`),
+			},
+		},
+	})
+}
+
+func TestAccPEP440VersionResourceUnknownInitialValue(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The initial version isn't known until the source is created
+			{
+				Config: `
+					resource terraform_data source {
+						input = 3
+					}
+
+					resource counter_pep440_version this {
+						major_initial_value = terraform_data.source.output
+						micro_triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("counter_pep440_version.this", tfjsonpath.New("value")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_pep440_version.this", "value", "3.0.0"),
+				),
+			},
+		},
+	})
+}
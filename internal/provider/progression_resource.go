@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"slices"
+	"strconv"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProgressionResource{}
+var _ resource.ResourceWithModifyPlan = &ProgressionResource{}
+var _ resource.ResourceWithImportState = &ProgressionResource{}
+var _ resource.ResourceWithConfigValidators = &ProgressionResource{}
+
+func NewProgressionResource() resource.Resource {
+	return &ProgressionResource{}
+}
+
+type ProgressionResource struct {
+}
+
+func (p ProgressionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_progression"
+}
+
+func (p ProgressionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A progression through an ordered list of stages, such as the canary weights of a progressive rollout, which advances one stage each time the triggers change and stays at the last stage until it is reset.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"current_stage": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current stage.",
+			},
+			"current_index": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The index of the current stage in `stages`.",
+			},
+			"is_complete": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the progression has reached the last stage.",
+			},
+			"stages": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "The stages to progress through in order, such as `[1, 5, 25, 50, 100]`. The current stage is found by its value, so stages can be added, removed or reordered as long as it remains.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of stages this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"history": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "A list of stages that this resource has moved to, including rollbacks and resets.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"stage": schema.StringAttribute{
+							Computed: true,
+						},
+						"index": schema.Int64Attribute{
+							Computed: true,
+						},
+						"triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"rollback_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"reset_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the progression to advance one stage when any of the values change. At the last stage the progression stays put.",
+			},
+			"rollback_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the progression to step back one stage when any of the values change. At the first stage the progression stays put. Takes precedence over `triggers`.",
+			},
+			"reset_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the progression to restart at the first stage when any of the values change. Takes precedence over `triggers` and `rollback_triggers`.",
+			},
+		},
+	}
+}
+
+func (p ProgressionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		triggersConfigured("triggers"),
+	}
+}
+
+func (p ProgressionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data progressionModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	resp.Diagnostics.Append(p.initialise(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (p ProgressionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (p ProgressionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	p.lifecycle().update(ctx, req, resp)
+}
+
+func (p ProgressionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (p ProgressionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	p.lifecycle().modifyPlan(ctx, req, resp)
+}
+
+func (p ProgressionResource) lifecycle() triggeredLifecycle[progressionModelV0] {
+	return triggeredLifecycle[progressionModelV0]{
+		known:      p.inputsKnown,
+		unknown:    p.setUnknownStage,
+		initialise: p.initialise,
+		advance:    p.advance,
+	}
+}
+
+func (p ProgressionResource) inputsKnown(data progressionModelV0) bool {
+	return mapFullyKnown(data.Triggers) && mapFullyKnown(data.RollbackTriggers) && mapFullyKnown(data.ResetTriggers) &&
+		listFullyKnown(data.Stages) && !data.MaxHistory.IsUnknown()
+}
+
+func (p ProgressionResource) setUnknownStage(data *progressionModelV0, creation bool) {
+	data.CurrentStage = types.StringUnknown()
+	data.CurrentIndex = types.Int64Unknown()
+	data.IsComplete = types.BoolUnknown()
+	data.History = types.ListUnknown(progressionHistoryEntryType)
+}
+
+// initialise starts a progression which is being created at the first stage.
+func (p ProgressionResource) initialise(ctx context.Context, data *progressionModelV0) diag.Diagnostics {
+	var stages []string
+	diags := data.Stages.ElementsAs(ctx, &stages, false)
+	if diags.HasError() {
+		return diags
+	}
+	p.setStage(data, stages, 0)
+	data.History = appendAndTruncate(ctx, types.ListNull(progressionHistoryEntryType), p.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return diags
+}
+
+// advance sets the stage and history from the prior state. A change to the reset triggers restarts the progression, a
+// change to the rollback triggers steps back one stage, and otherwise a change to the triggers advances one stage.
+// Moves beyond the first or last stage are ignored. The current stage is found by its value, so stages can be added,
+// removed or reordered as long as it remains.
+func (p ProgressionResource) advance(ctx context.Context, prior progressionModelV0, data *progressionModelV0, imported bool) diag.Diagnostics {
+	var stages []string
+	diags := data.Stages.ElementsAs(ctx, &stages, false)
+	if diags.HasError() {
+		return diags
+	}
+	index := int(prior.CurrentIndex.ValueInt64())
+	switch {
+	case prior.CurrentStage.IsNull():
+		// An imported progression only knows its index until the first plan settles the stage.
+		if index >= len(stages) {
+			diags.AddAttributeError(
+				path.Root("stages"),
+				"Current Stage Removed",
+				fmt.Sprintf("The progression was imported at index %d, but there are only %d stages.", index, len(stages)),
+			)
+			return diags
+		}
+	case index >= len(stages) || stages[index] != prior.CurrentStage.ValueString():
+		index = slices.Index(stages, prior.CurrentStage.ValueString())
+		if index < 0 {
+			diags.AddAttributeError(
+				path.Root("stages"),
+				"Current Stage Removed",
+				fmt.Sprintf("The current stage %q must remain in stages so that the progression can continue from it. Remove it after the progression has moved to another stage instead.", prior.CurrentStage.ValueString()),
+			)
+			return diags
+		}
+	}
+	p.setStage(data, stages, index)
+	data.History = prior.History
+
+	if imported {
+		data.History = replaceLastAndTruncate(ctx, prior.History, p.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+		return diags
+	}
+
+	next := index
+	switch {
+	case !prior.ResetTriggers.Equal(data.ResetTriggers):
+		next = 0
+	case !prior.RollbackTriggers.Equal(data.RollbackTriggers):
+		next = max(index-1, 0)
+	case !prior.Triggers.Equal(data.Triggers):
+		next = min(index+1, len(stages)-1)
+	}
+	if next != index {
+		p.setStage(data, stages, next)
+		data.History = appendAndTruncate(ctx, prior.History, p.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	}
+	return diags
+}
+
+func (p ProgressionResource) setStage(data *progressionModelV0, stages []string, index int) {
+	data.CurrentStage = types.StringValue(stages[index])
+	data.CurrentIndex = types.Int64Value(int64(index))
+	data.IsComplete = types.BoolValue(index == len(stages)-1)
+}
+
+func (p ProgressionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := parseImportId(req.ID, "current_index", "current_index", "max_history")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: %s. Expected the index of the current stage such as `2`, or `current_index=2,max_history=10`.", req.ID, err))
+		return
+	}
+
+	// The stages are taken from the configuration, so the current stage is settled by the first plan.
+	data := progressionModelV0{
+		Id:               types.StringValue(uuid.New().String()),
+		CurrentStage:     types.StringNull(),
+		IsComplete:       types.BoolNull(),
+		Stages:           types.ListNull(types.StringType),
+		MaxHistory:       types.Int64Value(defaultMaxHistory),
+		Triggers:         types.MapNull(types.StringType),
+		RollbackTriggers: types.MapNull(types.StringType),
+		ResetTriggers:    types.MapNull(types.StringType),
+	}
+	for attribute, raw := range values {
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Import Identifier", fmt.Sprintf("The value %q for %s is not a whole number.", raw, attribute))
+			continue
+		}
+		switch attribute {
+		case "current_index":
+			data.CurrentIndex = types.Int64Value(number)
+		case "max_history":
+			data.MaxHistory = types.Int64Value(number)
+		}
+	}
+	if data.CurrentIndex.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("current_index"), "Invalid Import Identifier", "The current_index must not be negative.")
+	}
+	if data.MaxHistory.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_history"), "Invalid Import Identifier", "The max_history must be at least 1.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.History = appendAndTruncate(ctx, types.ListNull(progressionHistoryEntryType), p.createHistoryEntry(data), data.MaxHistory.ValueInt64())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
+}
+
+var progressionHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"stage":             types.StringType,
+		"index":             types.Int64Type,
+		"triggers":          types.MapType{ElemType: types.StringType},
+		"rollback_triggers": types.MapType{ElemType: types.StringType},
+		"reset_triggers":    types.MapType{ElemType: types.StringType},
+	},
+}
+
+func (p ProgressionResource) createHistoryEntry(data progressionModelV0) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		progressionHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"stage":             data.CurrentStage,
+			"index":             data.CurrentIndex,
+			"triggers":          data.Triggers,
+			"rollback_triggers": data.RollbackTriggers,
+			"reset_triggers":    data.ResetTriggers,
+		},
+	)
+}
+
+type progressionModelV0 struct {
+	Id               types.String `tfsdk:"id"`
+	CurrentStage     types.String `tfsdk:"current_stage"`
+	CurrentIndex     types.Int64  `tfsdk:"current_index"`
+	IsComplete       types.Bool   `tfsdk:"is_complete"`
+	Stages           types.List   `tfsdk:"stages"`
+	MaxHistory       types.Int64  `tfsdk:"max_history"`
+	History          types.List   `tfsdk:"history"`
+	Triggers         types.Map    `tfsdk:"triggers"`
+	RollbackTriggers types.Map    `tfsdk:"rollback_triggers"`
+	ResetTriggers    types.Map    `tfsdk:"reset_triggers"`
+}
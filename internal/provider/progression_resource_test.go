@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"regexp"
+	"testing"
+)
+
+func progressionStep(stages string, hash string, rollback string, reset string) string {
+	return `
+		resource counter_progression this {
+			stages = [` + stages + `]
+			triggers = {
+				hash = "` + hash + `"
+			}
+			rollback_triggers = {
+				hash = "` + rollback + `"
+			}
+			reset_triggers = {
+				hash = "` + reset + `"
+			}
+		}
+	`
+}
+
+func TestAccProgressionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: progressionStep(`1, 5, 25`, "potatoes", "a", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "1"),
+					resource.TestCheckResourceAttr("counter_progression.this", "current_index", "0"),
+					resource.TestCheckResourceAttr("counter_progression.this", "is_complete", "false"),
+					resource.TestCheckResourceAttr("counter_progression.this", "history.0.stage", "1"),
+				),
+			},
+			{
+				Config: progressionStep(`1, 5, 25`, "eggs", "a", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "5"),
+				),
+			},
+			{
+				Config: progressionStep(`1, 5, 25`, "bacon", "a", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "25"),
+					resource.TestCheckResourceAttr("counter_progression.this", "is_complete", "true"),
+				),
+			},
+			// The progression stays at the last stage
+			{
+				Config: progressionStep(`1, 5, 25`, "toast", "a", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "25"),
+					resource.TestCheckResourceAttr("counter_progression.this", "history.#", "3"),
+				),
+			},
+			// A rollback takes precedence over the triggers
+			{
+				Config: progressionStep(`1, 5, 25`, "beans", "b", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "5"),
+					resource.TestCheckResourceAttr("counter_progression.this", "is_complete", "false"),
+					resource.TestCheckResourceAttr("counter_progression.this", "history.3.rollback_triggers.hash", "b"),
+				),
+			},
+			{
+				Config: progressionStep(`1, 5, 25`, "beans", "b", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "1"),
+					resource.TestCheckResourceAttr("counter_progression.this", "history.#", "5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProgressionResourceStages(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: progressionStep(`1, 5, 25`, "potatoes", "a", "a"),
+			},
+			{
+				Config: progressionStep(`1, 5, 25`, "eggs", "a", "a"),
+			},
+			// Changing the stages keeps the current stage
+			{
+				Config: progressionStep(`1, 2, 5, 50`, "eggs", "a", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "5"),
+					resource.TestCheckResourceAttr("counter_progression.this", "current_index", "2"),
+					resource.TestCheckResourceAttr("counter_progression.this", "history.#", "2"),
+				),
+			},
+			{
+				Config: progressionStep(`1, 2, 5, 50`, "bacon", "a", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "50"),
+					resource.TestCheckResourceAttr("counter_progression.this", "is_complete", "true"),
+				),
+			},
+			{
+				Config:      progressionStep(`1, 2, 5`, "bacon", "a", "a"),
+				ExpectError: regexp.MustCompile(`Current Stage Removed`),
+			},
+			{
+				Config:      progressionStep(``, "eggs", "a", "a"),
+				ExpectError: regexp.MustCompile(`must contain at least 1 elements`),
+			},
+		},
+	})
+}
+
+func TestAccProgressionResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: progressionStep(`1, 5, 25`, "potatoes", "a", "a"),
+			},
+			{
+				Config:             progressionStep(`1, 5, 25`, "potatoes", "a", "a"),
+				ResourceName:       "counter_progression.this",
+				ImportState:        true,
+				ImportStateId:      "1",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without moving
+			{
+				Config: progressionStep(`1, 5, 25`, "potatoes", "a", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "5"),
+					resource.TestCheckResourceAttr("counter_progression.this", "history.#", "1"),
+					resource.TestCheckResourceAttr("counter_progression.this", "history.0.triggers.hash", "potatoes"),
+				),
+			},
+			{
+				Config: progressionStep(`1, 5, 25`, "eggs", "a", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "25"),
+				),
+			},
+		},
+	})
+}
+
+func progressionUnknownStageStep(hash string, replace string) string {
+	return `
+		resource terraform_data source {
+			input            = "25"
+			triggers_replace = ["` + replace + `"]
+		}
+
+		resource counter_progression this {
+			stages = ["5", terraform_data.source.output]
+			triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccProgressionResourceUnknownStages(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: progressionUnknownStageStep("potatoes", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "5"),
+				),
+			},
+			// Replacing the source makes one of the stages unknown during plan, so the next stage can't be planned
+			// until it is known
+			{
+				Config: progressionUnknownStageStep("eggs", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("counter_progression.this", tfjsonpath.New("current_stage")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_progression.this", "current_stage", "25"),
+					resource.TestCheckResourceAttr("counter_progression.this", "history.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -2,11 +2,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"time"
 )
 
 // Ensure CounterProvider satisfies various provider interfaces.
@@ -23,6 +27,13 @@ type CounterProvider struct {
 
 // CounterProviderModel describes the provider data model.
 type CounterProviderModel struct {
+	FixedTime types.String `tfsdk:"fixed_time"`
+}
+
+// counterProviderData is passed to the resources and data sources which depend on the provider configuration.
+type counterProviderData struct {
+	// now returns the current time, which fixed_time replaces so that time based counters can be tested.
+	now func() time.Time
 }
 
 func (p *CounterProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -32,7 +43,12 @@ func (p *CounterProvider) Metadata(ctx context.Context, req provider.MetadataReq
 
 func (p *CounterProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Attributes: map[string]schema.Attribute{},
+		Attributes: map[string]schema.Attribute{
+			"fixed_time": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "An [RFC 3339](https://www.rfc-editor.org/rfc/rfc3339) timestamp, such as `2024-05-17T09:30:00Z`, used as the current time by time based counters such as `counter_calendar_version`. Intended for testing.",
+			},
+		},
 	}
 }
 
@@ -44,21 +60,60 @@ func (p *CounterProvider) Configure(ctx context.Context, req provider.ConfigureR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if data.FixedTime.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("fixed_time"), "Unknown Fixed Time", "The fixed_time must be known when the provider is configured, so it can't depend on resources which haven't been created yet.")
+		return
+	}
+
+	providerData := &counterProviderData{now: time.Now}
+	if !data.FixedTime.IsNull() {
+		fixed, err := time.Parse(time.RFC3339, data.FixedTime.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("fixed_time"), "Invalid Fixed Time", fmt.Sprintf("Unable to parse %q as an RFC 3339 timestamp: %s.", data.FixedTime.ValueString(), err))
+			return
+		}
+		providerData.now = func() time.Time { return fixed }
+	}
+	resp.ResourceData = providerData
+	resp.DataSourceData = providerData
 }
 
 func (p *CounterProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewMonotonicResource,
+		NewMonotonicMapResource,
 		NewSemanticVersionResource,
+		NewCalendarVersionResource,
+		NewDNSSerialResource,
+		NewPEP440VersionResource,
+		NewAssemblyVersionResource,
+		NewPackageVersionResource,
+		NewRevisionLetterResource,
+		NewAllocationResource,
+		NewCIDRAllocationResource,
+		NewToggleResource,
+		NewProgressionResource,
 	}
 }
 
 func (p *CounterProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewNextDataSource,
+	}
 }
 
 func (p *CounterProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewSemverParseFunction,
+		NewSemverCompareFunction,
+		NewSemverSortFunction,
+		NewSemverMaxFunction,
+		NewSemverSatisfiesFunction,
+		NewSemverBumpFunction,
+		NewMonotonicNextFunction,
+		NewFormatCounterFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -1,10 +1,12 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -20,3 +22,71 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// testUpgradeResourceState runs the provider's state upgrade for raw state stored at the given schema version and
+// returns the upgraded state.
+func testUpgradeResourceState(t *testing.T, typeName string, version int64, rawState string) tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+	server, err := testAccProtoV6ProviderFactories["counter"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(rawState)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+	state, err := resp.UpgradedState.Unmarshal(schemas.ResourceSchemas[typeName].ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+// testStateAttribute returns the value at the given attribute path of a state value.
+func testStateAttribute(t *testing.T, state tftypes.Value, steps ...tftypes.AttributePathStep) tftypes.Value {
+	t.Helper()
+	attributePath := tftypes.NewAttributePathWithSteps(steps)
+	value, _, err := tftypes.WalkAttributePath(state, attributePath)
+	if err != nil {
+		t.Fatalf("%s: %s", attributePath, err)
+	}
+	return value.(tftypes.Value)
+}
+
+func TestProviderConfigureUnknownFixedTime(t *testing.T) {
+	ctx := context.Background()
+	server, err := testAccProtoV6ProviderFactories["counter"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configType := schemas.Provider.ValueType()
+	config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, map[string]tftypes.Value{
+		"fixed_time": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Unknown Fixed Time" {
+		t.Fatalf("expected an Unknown Fixed Time diagnostic, got %v", resp.Diagnostics)
+	}
+}
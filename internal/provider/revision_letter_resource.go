@@ -0,0 +1,388 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RevisionLetterResource{}
+var _ resource.ResourceWithModifyPlan = &RevisionLetterResource{}
+var _ resource.ResourceWithImportState = &RevisionLetterResource{}
+var _ resource.ResourceWithConfigValidators = &RevisionLetterResource{}
+var _ resource.ResourceWithValidateConfig = &RevisionLetterResource{}
+
+// revisionLetterPattern matches a revision such as `B` or `AC.2`.
+var revisionLetterPattern = regexp.MustCompile(`^([A-Z]+)(?:\.([0-9]+))?$`)
+
+// defaultExcludedLetters are skipped by default as they are easily mistaken for the digits 1 and 0, as recommended by
+// ASME Y14.35.
+var defaultExcludedLetters = []string{"I", "O", "Q"}
+
+func defaultExcludedLettersValue() types.Set {
+	var letters []attr.Value
+	for _, letter := range defaultExcludedLetters {
+		letters = append(letters, types.StringValue(letter))
+	}
+	return types.SetValueMust(types.StringType, letters)
+}
+
+func NewRevisionLetterResource() resource.Resource {
+	return &RevisionLetterResource{}
+}
+
+type RevisionLetterResource struct {
+}
+
+func (r RevisionLetterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_revision_letter"
+}
+
+func (r RevisionLetterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A revision letter for drawings and documents which moves through `A` to `Z`, then `AA`, `AB` and so on according to the configured triggers, with an optional numeric sub-revision such as `B.2`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current revision, which is the letter followed by the sub-revision when there is one, such as `B` or `B.2`.",
+			},
+			"letter": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current revision letter.",
+			},
+			"sub_revision": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current sub-revision, or null until `sub_revision_triggers` change after the letter did.",
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of revisions this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"history": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "A list of revisions that this resource has produced.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+						"triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"sub_revision_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"initial_letter": schema.StringAttribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             stringdefault.StaticString("A"),
+				MarkdownDescription: "The initial revision letter, which must not use any of `excluded_letters`. Defaults to `A`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Z]+$`), "must only contain upper case letters"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"excluded_letters": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Optional:            true,
+				Default:             setdefault.StaticValue(defaultExcludedLettersValue()),
+				MarkdownDescription: "Letters which are skipped, as they are easily confused with digits. Changing them only affects later revisions. Defaults to `I`, `O` and `Q`.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Z]$`), "must be a single upper case letter")),
+					setvalidator.SizeAtMost(25),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the revision letter to advance and the sub-revision to be removed when any of the values change.",
+			},
+			"sub_revision_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the sub-revision to increment, starting at 1, when any of the values change.",
+			},
+		},
+	}
+}
+
+func (r RevisionLetterResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		triggersConfigured("triggers", "sub_revision_triggers"),
+	}
+}
+
+func (r RevisionLetterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data revisionLetterModelV0
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.InitialLetter.IsNull() || data.InitialLetter.IsUnknown() || !setFullyKnown(data.ExcludedLetters) {
+		return
+	}
+
+	alphabet := defaultExcludedLetters
+	if !data.ExcludedLetters.IsNull() {
+		alphabet = nil
+		resp.Diagnostics.Append(data.ExcludedLetters.ElementsAs(ctx, &alphabet, false)...)
+	}
+	for _, letter := range alphabet {
+		if strings.Contains(data.InitialLetter.ValueString(), letter) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("initial_letter"),
+				"Excluded Initial Letter",
+				fmt.Sprintf("The initial letter %q uses the excluded letter %q.", data.InitialLetter.ValueString(), letter),
+			)
+			return
+		}
+	}
+}
+
+func (r RevisionLetterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data revisionLetterModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	resp.Diagnostics.Append(r.initialise(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r RevisionLetterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (r RevisionLetterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.lifecycle().update(ctx, req, resp)
+}
+
+func (r RevisionLetterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r RevisionLetterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.lifecycle().modifyPlan(ctx, req, resp)
+}
+
+func (r RevisionLetterResource) lifecycle() triggeredLifecycle[revisionLetterModelV0] {
+	return triggeredLifecycle[revisionLetterModelV0]{
+		known:      r.inputsKnown,
+		unknown:    r.setUnknownRevision,
+		initialise: r.initialise,
+		advance:    r.advance,
+	}
+}
+
+func (r RevisionLetterResource) inputsKnown(data revisionLetterModelV0) bool {
+	return mapFullyKnown(data.Triggers) && mapFullyKnown(data.SubRevisionTriggers) && setFullyKnown(data.ExcludedLetters) &&
+		!data.InitialLetter.IsUnknown() && !data.MaxHistory.IsUnknown()
+}
+
+func (r RevisionLetterResource) setUnknownRevision(data *revisionLetterModelV0, creation bool) {
+	if creation && !data.InitialLetter.IsUnknown() {
+		r.setRevision(data, data.InitialLetter.ValueString(), types.Int64Null())
+	} else {
+		data.Value = types.StringUnknown()
+		data.Letter = types.StringUnknown()
+		data.SubRevision = types.Int64Unknown()
+	}
+	data.History = types.ListUnknown(revisionLetterHistoryEntryType)
+}
+
+// initialise sets the revision and history of a resource which is being created.
+func (r RevisionLetterResource) initialise(ctx context.Context, data *revisionLetterModelV0) diag.Diagnostics {
+	r.setRevision(data, data.InitialLetter.ValueString(), types.Int64Null())
+	data.History = appendAndTruncate(ctx, types.ListNull(revisionLetterHistoryEntryType), r.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return nil
+}
+
+// advance sets the revision and history from the prior state. A change to the triggers advances the letter and
+// removes the sub-revision, and otherwise a change to the sub-revision triggers increments the sub-revision.
+func (r RevisionLetterResource) advance(ctx context.Context, prior revisionLetterModelV0, data *revisionLetterModelV0, imported bool) diag.Diagnostics {
+	r.setRevision(data, prior.Letter.ValueString(), prior.SubRevision)
+	data.History = prior.History
+
+	if imported {
+		data.History = replaceLastAndTruncate(ctx, prior.History, r.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+		return nil
+	}
+
+	switch {
+	case !prior.Triggers.Equal(data.Triggers):
+		var excluded []string
+		if diags := data.ExcludedLetters.ElementsAs(ctx, &excluded, false); diags.HasError() {
+			return diags
+		}
+		r.setRevision(data, nextRevisionLetter(prior.Letter.ValueString(), excluded), types.Int64Null())
+	case !prior.SubRevisionTriggers.Equal(data.SubRevisionTriggers):
+		r.setRevision(data, prior.Letter.ValueString(), types.Int64Value(prior.SubRevision.ValueInt64()+1))
+	default:
+		return nil
+	}
+	data.History = appendAndTruncate(ctx, prior.History, r.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return nil
+}
+
+// nextRevisionLetter returns the letter after the given one, skipping the excluded letters. Like spreadsheet columns,
+// `Z` is followed by `AA` and `AZ` by `BA`.
+func nextRevisionLetter(letter string, excluded []string) string {
+	skipped := strings.Join(excluded, "")
+	var alphabet []byte
+	for c := byte('A'); c <= 'Z'; c++ {
+		if !strings.ContainsRune(skipped, rune(c)) {
+			alphabet = append(alphabet, c)
+		}
+	}
+
+	next := []byte(letter)
+	for i := len(next) - 1; i >= 0; i-- {
+		for _, c := range alphabet {
+			if c > next[i] {
+				next[i] = c
+				return string(next)
+			}
+		}
+		next[i] = alphabet[0]
+	}
+	return string(alphabet[0]) + string(next)
+}
+
+func (r RevisionLetterResource) setRevision(data *revisionLetterModelV0, letter string, subRevision types.Int64) {
+	data.Letter = types.StringValue(letter)
+	data.SubRevision = subRevision
+	if subRevision.IsNull() {
+		data.Value = types.StringValue(letter)
+	} else {
+		data.Value = types.StringValue(fmt.Sprintf("%s.%d", letter, subRevision.ValueInt64()))
+	}
+}
+
+func (r RevisionLetterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := parseImportId(req.ID, "value", "value", "initial_letter", "max_history")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: %s. Expected a revision such as `C` or `C.2`, or `value=C.2,initial_letter=A`.", req.ID, err))
+		return
+	}
+
+	data := revisionLetterModelV0{
+		Id:                  types.StringValue(uuid.New().String()),
+		MaxHistory:          types.Int64Value(defaultMaxHistory),
+		InitialLetter:       types.StringValue("A"),
+		ExcludedLetters:     defaultExcludedLettersValue(),
+		Triggers:            types.MapNull(types.StringType),
+		SubRevisionTriggers: types.MapNull(types.StringType),
+	}
+	if initial, ok := values["initial_letter"]; ok {
+		if !regexp.MustCompile(`^[A-Z]+$`).MatchString(initial) {
+			resp.Diagnostics.AddAttributeError(path.Root("initial_letter"), "Invalid Import Identifier", fmt.Sprintf("The initial letter %q must only contain upper case letters.", initial))
+		}
+		data.InitialLetter = types.StringValue(initial)
+	}
+	if raw, ok := values["max_history"]; ok {
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || number < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_history"), "Invalid Import Identifier", fmt.Sprintf("The value %q for max_history must be a whole number of at least 1.", raw))
+		}
+		data.MaxHistory = types.Int64Value(number)
+	}
+	matches := revisionLetterPattern.FindStringSubmatch(values["value"])
+	if matches == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid Import Identifier", fmt.Sprintf("The revision %q must be upper case letters, optionally followed by a sub-revision such as `.2`.", values["value"]))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subRevision := types.Int64Null()
+	if matches[2] != "" {
+		number, err := strconv.ParseInt(matches[2], 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid Import Identifier", fmt.Sprintf("The sub-revision of %q is not a whole number.", values["value"]))
+			return
+		}
+		subRevision = types.Int64Value(number)
+	}
+	r.setRevision(&data, matches[1], subRevision)
+	data.History = appendAndTruncate(ctx, types.ListNull(revisionLetterHistoryEntryType), r.createHistoryEntry(data), data.MaxHistory.ValueInt64())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
+}
+
+var revisionLetterHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":                 types.StringType,
+		"triggers":              types.MapType{ElemType: types.StringType},
+		"sub_revision_triggers": types.MapType{ElemType: types.StringType},
+	},
+}
+
+func (r RevisionLetterResource) createHistoryEntry(data revisionLetterModelV0) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		revisionLetterHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"value":                 data.Value,
+			"triggers":              data.Triggers,
+			"sub_revision_triggers": data.SubRevisionTriggers,
+		},
+	)
+}
+
+type revisionLetterModelV0 struct {
+	Id                  types.String `tfsdk:"id"`
+	Value               types.String `tfsdk:"value"`
+	Letter              types.String `tfsdk:"letter"`
+	SubRevision         types.Int64  `tfsdk:"sub_revision"`
+	MaxHistory          types.Int64  `tfsdk:"max_history"`
+	History             types.List   `tfsdk:"history"`
+	InitialLetter       types.String `tfsdk:"initial_letter"`
+	ExcludedLetters     types.Set    `tfsdk:"excluded_letters"`
+	Triggers            types.Map    `tfsdk:"triggers"`
+	SubRevisionTriggers types.Map    `tfsdk:"sub_revision_triggers"`
+}
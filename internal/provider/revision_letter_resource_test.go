@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"regexp"
+	"testing"
+)
+
+func revisionLetterStep(letter string, subRevision string) string {
+	return `
+		resource counter_revision_letter this {
+			initial_letter = "H"
+			triggers = {
+				hash = "` + letter + `"
+			}
+			sub_revision_triggers = {
+				hash = "` + subRevision + `"
+			}
+		}
+	`
+}
+
+func TestAccRevisionLetterResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: revisionLetterStep("potatoes", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "value", "H"),
+					resource.TestCheckNoResourceAttr("counter_revision_letter.this", "sub_revision"),
+				),
+			},
+			{
+				Config: revisionLetterStep("potatoes", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "value", "H.1"),
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "sub_revision", "1"),
+				),
+			},
+			{
+				Config: revisionLetterStep("potatoes", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "value", "H.2"),
+				),
+			},
+			// A new letter skips I and removes the sub-revision
+			{
+				Config: revisionLetterStep("eggs", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "value", "J"),
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "letter", "J"),
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "history.#", "4"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRevisionLetterResourceWrap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource counter_revision_letter this {
+						initial_letter   = "AZ"
+						excluded_letters = ["B"]
+						triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "value", "AZ"),
+				),
+			},
+			{
+				Config: `
+					resource counter_revision_letter this {
+						initial_letter   = "AZ"
+						excluded_letters = ["B"]
+						triggers = {
+							hash = "eggs"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "value", "CA"),
+				),
+			},
+			{
+				Config: `
+					resource counter_revision_letter this {
+						initial_letter = "O"
+						triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`uses the excluded letter "O"`),
+			},
+		},
+	})
+}
+
+func TestAccRevisionLetterResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: revisionLetterStep("potatoes", "potatoes"),
+			},
+			{
+				Config:             revisionLetterStep("potatoes", "potatoes"),
+				ResourceName:       "counter_revision_letter.this",
+				ImportState:        true,
+				ImportStateId:      "value=N.3,initial_letter=H",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without advancing
+			{
+				Config: revisionLetterStep("potatoes", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "value", "N.3"),
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "history.#", "1"),
+				),
+			},
+			{
+				Config: revisionLetterStep("eggs", "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "value", "P"),
+				),
+			},
+		},
+	})
+}
+
+func revisionLetterUnknownExcludedStep(hash string, replace string) string {
+	return `
+		resource terraform_data source {
+			input            = "S"
+			triggers_replace = ["` + replace + `"]
+		}
+
+		resource counter_revision_letter this {
+			initial_letter   = "R"
+			excluded_letters = ["I", "O", terraform_data.source.output]
+			triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccRevisionLetterResourceUnknownExcludedLetters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: revisionLetterUnknownExcludedStep("potatoes", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "value", "R"),
+				),
+			},
+			// Replacing the source makes one of the excluded letters unknown during plan, so the next letter can't be
+			// planned until it is known
+			{
+				Config: revisionLetterUnknownExcludedStep("eggs", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("counter_revision_letter.this", tfjsonpath.New("value")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "value", "T"),
+					resource.TestCheckResourceAttr("counter_revision_letter.this", "history.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -4,22 +4,43 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SemanticVersionResource{}
 var _ resource.ResourceWithModifyPlan = &SemanticVersionResource{}
+var _ resource.ResourceWithImportState = &SemanticVersionResource{}
+var _ resource.ResourceWithConfigValidators = &SemanticVersionResource{}
+var _ resource.ResourceWithValidateConfig = &SemanticVersionResource{}
+
+var defaultPrereleaseChannels = types.ListValueMust(types.StringType, []attr.Value{
+	types.StringValue("alpha"),
+	types.StringValue("beta"),
+	types.StringValue("rc"),
+})
 
 func NewSemanticVersionResource() resource.Resource {
 	return &SemanticVersionResource{}
@@ -34,6 +55,7 @@ func (s SemanticVersionResource) Metadata(ctx context.Context, req resource.Meta
 
 func (s SemanticVersionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: semanticVersionSchemaVersion,
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "A semantic version number whose components increment according to the configured triggers.",
 		Attributes: map[string]schema.Attribute{
@@ -67,7 +89,27 @@ func (s SemanticVersionResource) Schema(ctx context.Context, req resource.Schema
 			},
 			"value": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "The semantic version number as a string in `<major>.<minor>.<patch>` form.",
+				MarkdownDescription: "The semantic version number as a string in `<major>.<minor>.<patch>[-<prerelease>]` form.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value_with_metadata": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The semantic version number including `build_metadata`, in `<major>.<minor>.<patch>[-<prerelease>][+<build>]` form.",
+			},
+			"formatted_value": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The version rendered with `format`.",
+			},
+			"preset_values": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "The version rendered with each of the `format` presets, keyed by preset name.",
+			},
+			"prerelease_value": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The prerelease part of the version, such as `beta.3`, or null when the version is a release.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -75,8 +117,11 @@ func (s SemanticVersionResource) Schema(ctx context.Context, req resource.Schema
 			"max_history": schema.Int64Attribute{
 				Computed:            true,
 				Optional:            true,
-				Default:             int64default.StaticInt64(1000),
-				MarkdownDescription: "Maximum number of versions this resource should store in the `history` attribute.",
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of versions this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"history": schema.ListNestedAttribute{
 				Computed:            true,
@@ -107,6 +152,23 @@ func (s SemanticVersionResource) Schema(ctx context.Context, req resource.Schema
 							ElementType: types.StringType,
 							Computed:    true,
 						},
+						"prerelease_value": schema.StringAttribute{
+							Computed: true,
+						},
+						"prerelease_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"stabilize_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"build_metadata": schema.StringAttribute{
+							Computed: true,
+						},
+						"formatted_value": schema.StringAttribute{
+							Computed: true,
+						},
 					},
 				},
 				PlanModifiers: []planmodifier.List{
@@ -117,7 +179,10 @@ func (s SemanticVersionResource) Schema(ctx context.Context, req resource.Schema
 				Computed:            true,
 				Optional:            true,
 				Default:             int64default.StaticInt64(1),
-				MarkdownDescription: "The initial major version value.",
+				MarkdownDescription: "The initial major version value. Must not be negative.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.RequiresReplace(),
 				},
@@ -125,8 +190,11 @@ func (s SemanticVersionResource) Schema(ctx context.Context, req resource.Schema
 			"minor_initial_value": schema.Int64Attribute{
 				Computed:            true,
 				Optional:            true,
-				MarkdownDescription: "The initial minor version value.",
-				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The initial minor version value. Must not be negative.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Default: int64default.StaticInt64(0),
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.RequiresReplace(),
 				},
@@ -135,15 +203,62 @@ func (s SemanticVersionResource) Schema(ctx context.Context, req resource.Schema
 				Computed:            true,
 				Optional:            true,
 				Default:             int64default.StaticInt64(0),
-				MarkdownDescription: "The initial patch version value.",
+				MarkdownDescription: "The initial patch version value. Must not be negative.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.RequiresReplace(),
 				},
 			},
+			"major_step": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "The amount the major version number increments by. Must be at least 1. Defaults to 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"minor_step": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "The amount the minor version number increments by. Must be at least 1. Defaults to 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"patch_step": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "The amount the patch version number increments by. Must be at least 1. Defaults to 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"reset_lower_components": schema.MapAttribute{
+				ElementType:         types.BoolType,
+				Optional:            true,
+				MarkdownDescription: "Whether incrementing a component resets the lower components to 0, keyed by `major` or `minor`. For example `{ minor = false }` keeps the patch number increasing across minor versions. Components which aren't set reset the lower components.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf("major", "minor")),
+				},
+			},
+			"simultaneous_changes": schema.StringAttribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             stringdefault.StaticString("highest_wins"),
+				MarkdownDescription: "How changes to the triggers of several components at once are handled. With `highest_wins` only the highest changed component increments, and with `cumulative` every changed component increments, from major to patch. Defaults to `highest_wins`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("highest_wins", "cumulative"),
+				},
+			},
 			"major_triggers": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "A map of strings that will cause the major version number to increment when any of the values change.",
+				MarkdownDescription: "A map of strings that will cause the major version number to increment when any of the values change. The minor version increments instead while `initial_development` is enabled and the major version is 0.",
 			},
 			"minor_triggers": schema.MapAttribute{
 				ElementType:         types.StringType,
@@ -155,10 +270,122 @@ func (s SemanticVersionResource) Schema(ctx context.Context, req resource.Schema
 				Optional:            true,
 				MarkdownDescription: "A map of strings that will cause the patch version number to increment when any of the values change.",
 			},
+			"prerelease_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the prerelease number to increment when any of the values change while the version is a prerelease.",
+			},
+			"stabilize_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will graduate a `0.y.z` version to `1.0.0` when any of the values change. Changes have no effect once the major version is at least 1.",
+			},
+			"initial_development": schema.BoolAttribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Follow the SemVer rules for initial development, where changes to `major_triggers` increment the minor version while the major version is 0. Use `stabilize_triggers` to graduate to `1.0.0`, and set `major_initial_value` to 0 to start in initial development.",
+			},
+			"prerelease_channel": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The prerelease channel, such as `beta`, which must be one of `prerelease_channels`. Versions start at `<channel>.1` and move to the next channel or, when this is removed, to the final release. A release entering a channel without another change becomes a prerelease of the next patch version. Moving back to an earlier channel of the same version is an error.",
+			},
+			"prerelease_channels": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Optional:            true,
+				Default:             listdefault.StaticValue(defaultPrereleaseChannels),
+				MarkdownDescription: "The ordered prerelease channels a version is promoted through. Channels must be alphanumeric SemVer identifiers listed in ascending precedence. Defaults to `[\"alpha\", \"beta\", \"rc\"]`.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(prereleaseChannelPattern, "must be an alphanumeric SemVer prerelease identifier")),
+				},
+			},
+			"build_metadata": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "SemVer build metadata, such as `sha.abc123.build.77`, appended to `value_with_metadata`. Changes never cause the version to increment; the metadata in effect when a version is produced is recorded in `history`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(buildMetadataPattern, "must be dot separated alphanumeric SemVer build identifiers"),
+				},
+			},
+			"format": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("semver"),
+				MarkdownDescription: "How `formatted_value` is rendered. Either a template such as `v{major}.{minor}.{patch}`, or one of the presets `semver`, `npm`, `nuget`, `helm` and `oci`. Templates may use the `{major}`, `{minor}`, `{patch}`, `{prerelease}` and `{build}` placeholders, optionally with a separator such as `{-prerelease}` which is only rendered when the component is present. The `npm` and `nuget` presets omit build metadata and `oci` separates it with `_`, as image tags can't contain `+`. Changes never cause the version to increment. Defaults to `semver`.",
+			},
+			"reuse_previous_values": schema.BoolAttribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "When the triggers change back to a combination recorded in `history`, reuse the version produced for it in the same prerelease channel instead of incrementing. New combinations skip any version already recorded in `history`.",
+			},
 		},
 	}
 }
 
+func (s SemanticVersionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		triggersConfigured("major_triggers", "minor_triggers", "patch_triggers", "prerelease_triggers", "stabilize_triggers"),
+	}
+}
+
+func (s SemanticVersionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var format types.String
+	var channel types.String
+	var channels types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("format"), &format)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("prerelease_channel"), &channel)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("prerelease_channels"), &channels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !format.IsNull() && !format.IsUnknown() {
+		if err := validateVersionFormat(format.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("format"), "Invalid Version Format", fmt.Sprintf("The format %s.", err))
+		}
+	}
+
+	if channels.IsUnknown() {
+		return
+	}
+
+	var names []string
+	if channels.IsNull() {
+		resp.Diagnostics.Append(defaultPrereleaseChannels.ElementsAs(ctx, &names, false)...)
+	} else {
+		var elements []types.String
+		resp.Diagnostics.Append(channels.ElementsAs(ctx, &elements, false)...)
+		for _, element := range elements {
+			if element.IsUnknown() {
+				return
+			}
+			names = append(names, element.ValueString())
+		}
+	}
+
+	// Channels are compared as SemVer prerelease identifiers, so promotion must follow their precedence.
+	for i := 1; i < len(names); i++ {
+		if comparePrereleaseIdentifiers(names[i-1], names[i]) >= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("prerelease_channels"),
+				"Invalid Prerelease Channel Order",
+				fmt.Sprintf("The channel %q must have a higher SemVer precedence than %q, which precedes it. Channels are compared in ASCII order, so versions in later channels would otherwise sort before earlier ones.", names[i], names[i-1]),
+			)
+		}
+	}
+
+	if !channel.IsNull() && !channel.IsUnknown() && !slices.Contains(names, channel.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("prerelease_channel"),
+			"Unknown Prerelease Channel",
+			fmt.Sprintf("The prerelease channel %q must be one of: %s.", channel.ValueString(), strings.Join(names, ", ")),
+		)
+	}
+}
+
 func (s SemanticVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data semanticVersionModelV1
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -166,6 +393,10 @@ func (s SemanticVersionResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 	data.Id = types.StringValue(uuid.New().String())
+	resp.Diagnostics.Append(s.initialise(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -174,145 +405,439 @@ func (s SemanticVersionResource) Read(ctx context.Context, req resource.ReadRequ
 }
 
 func (s SemanticVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data semanticVersionModelV1
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	s.lifecycle().update(ctx, req, resp)
 }
 
 func (s SemanticVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 }
 
 func (s SemanticVersionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// If the entire plan is null, the resource is planned for destruction.
-	if req.Plan.Raw.IsNull() {
-		return
+	s.lifecycle().modifyPlan(ctx, req, resp)
+}
+
+func (s SemanticVersionResource) lifecycle() triggeredLifecycle[semanticVersionModelV1] {
+	return triggeredLifecycle[semanticVersionModelV1]{
+		known:      func(data semanticVersionModelV1) bool { return !s.hasUnknownInputs(data) },
+		unknown:    s.setUnknownInputsVersion,
+		initialise: s.initialise,
+		advance:    s.advance,
 	}
+}
 
-	var majorValue types.Int64
-	var minorValue types.Int64
-	var patchValue types.Int64
-	var maxHistory types.Int64
-	var majorTriggers types.Map
-	var minorTriggers types.Map
-	var patchTriggers types.Map
-	creation := req.State.Raw.IsNull()
-
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("major_triggers"), &majorTriggers)...)
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("minor_triggers"), &minorTriggers)...)
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("patch_triggers"), &patchTriggers)...)
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("max_history"), &maxHistory)...)
-
-	if creation {
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("major_initial_value"), &majorValue)...)
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("minor_initial_value"), &minorValue)...)
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("patch_initial_value"), &patchValue)...)
-
-		value := types.StringValue(fmt.Sprintf("%d.%d.%d", majorValue.ValueInt64(), minorValue.ValueInt64(), patchValue.ValueInt64()))
-		history := appendAndTruncate([]basetypes.ObjectValue{}, s.createHistoryEntry(value, majorValue, majorTriggers, minorValue, minorTriggers, patchValue, patchTriggers), maxHistory.ValueInt64())
-
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value"), value)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("major_value"), majorValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("minor_value"), minorValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("patch_value"), patchValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("history"), history)...)
-		return
+// setUnknownInputsVersion plans the version of a new resource, which starts at the initial version whatever the
+// triggers are, once the initial values and prerelease channel are known.
+func (s SemanticVersionResource) setUnknownInputsVersion(data *semanticVersionModelV1, creation bool) {
+	if creation && s.initialVersionKnown(*data) {
+		s.setVersion(data, s.initialVersion(*data))
+	} else {
+		s.setUnknownVersion(data)
 	}
+	data.History = types.ListUnknown(semanticVersionHistoryEntryType)
+}
 
-	if !mapAttributeIsEqual(ctx, req, resp, "major_triggers") {
-		var history []basetypes.ObjectValue
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("major_value"), &majorValue)...)
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("history"), &history)...)
+func (s SemanticVersionResource) hasUnknownInputs(data semanticVersionModelV1) bool {
+	return !mapFullyKnown(data.MajorTriggers) || !mapFullyKnown(data.MinorTriggers) || !mapFullyKnown(data.PatchTriggers) ||
+		!mapFullyKnown(data.PrereleaseTriggers) || !mapFullyKnown(data.StabilizeTriggers) || data.PrereleaseChannel.IsUnknown() ||
+		data.InitialDevelopment.IsUnknown() || data.MajorStep.IsUnknown() || data.MinorStep.IsUnknown() ||
+		data.PatchStep.IsUnknown() || !mapFullyKnown(data.ResetLowerComponents) || data.SimultaneousChanges.IsUnknown() ||
+		data.MaxHistory.IsUnknown() || !s.initialVersionKnown(data)
+}
 
-		majorValue = types.Int64Value(majorValue.ValueInt64() + 1)
-		minorValue = types.Int64Value(0)
-		patchValue = types.Int64Value(0)
-		value := types.StringValue(fmt.Sprintf("%d.%d.%d", majorValue.ValueInt64(), minorValue.ValueInt64(), patchValue.ValueInt64()))
-		history = appendAndTruncate(history, s.createHistoryEntry(value, majorValue, majorTriggers, minorValue, minorTriggers, patchValue, patchTriggers), maxHistory.ValueInt64())
+func (s SemanticVersionResource) initialVersionKnown(data semanticVersionModelV1) bool {
+	return !data.MajorInitialValue.IsUnknown() && !data.MinorInitialValue.IsUnknown() && !data.PatchInitialValue.IsUnknown() &&
+		!data.PrereleaseChannel.IsUnknown()
+}
 
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value"), value)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("major_value"), majorValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("minor_value"), minorValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("patch_value"), patchValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("history"), history)...)
-		return
+func (s SemanticVersionResource) initialVersion(data semanticVersionModelV1) semanticVersion {
+	version := semanticVersion{
+		major: data.MajorInitialValue.ValueInt64(),
+		minor: data.MinorInitialValue.ValueInt64(),
+		patch: data.PatchInitialValue.ValueInt64(),
+	}
+	if channel := data.PrereleaseChannel.ValueString(); channel != "" {
+		version.prerelease = []string{channel, "1"}
+	}
+	return version
+}
+
+// initialise sets the version and history of a resource which is being created.
+func (s SemanticVersionResource) initialise(ctx context.Context, data *semanticVersionModelV1) diag.Diagnostics {
+	s.setVersion(data, s.initialVersion(*data))
+	data.History = appendAndTruncate(ctx, types.ListNull(semanticVersionHistoryEntryType), s.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return nil
+}
+
+// advance sets the version and history from the prior state according to the triggers and prerelease channel which
+// changed. It is used both while planning and, when the triggers were unknown during planning, while applying.
+func (s SemanticVersionResource) advance(ctx context.Context, prior semanticVersionModelV1, data *semanticVersionModelV1, imported bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	current, err := parseSemanticVersion(prior.Value.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Resource State", fmt.Sprintf("Unable to parse the current version: %s.", err))
+		return diags
+	}
+	s.setVersion(data, current)
+	data.History = prior.History
+
+	if imported {
+		// Adopting a version from another prerelease channel would change it on the next plan without a change to the
+		// configuration.
+		if channel := data.PrereleaseChannel.ValueString(); channel != current.channel() {
+			diags.AddAttributeError(
+				path.Root("prerelease_channel"),
+				"Imported Prerelease Channel Mismatch",
+				fmt.Sprintf("The imported version %s requires prerelease_channel to be %s, but it is %s. Configure the channel of the imported version, and change it after the import has been applied.", current, describePrereleaseChannel(current.channel()), describePrereleaseChannel(channel)),
+			)
+			return diags
+		}
+		data.History = replaceLastAndTruncate(ctx, prior.History, s.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+		return diags
+	}
+
+	bump := s.bumpFor(prior, *data, current)
+	if bump == nil {
+		return diags
+	}
+
+	next := bump(current)
+	reused, found := s.previousVersion(prior.History, *data)
+	if found {
+		next, err = parseSemanticVersion(reused.Attributes()["value"].(types.String).ValueString())
+		if err != nil {
+			diags.AddError("Invalid Resource State", fmt.Sprintf("Unable to parse a version in history: %s.", err))
+			return diags
+		}
+	} else if data.ReusePreviousValues.ValueBool() {
+		// New trigger combinations never receive a version which was previously produced for another combination.
+		for historyContains(prior.History, "value", types.StringValue(next.String())) {
+			bumped := bump(next)
+			if bumped.compare(next) == 0 {
+				break
+			}
+			next = bumped
+		}
+	}
+
+	if !found && next.compare(current) <= 0 {
+		diags.AddAttributeError(
+			path.Root("prerelease_channel"),
+			"Version Would Go Backwards",
+			fmt.Sprintf("The configuration would change the version from %s to %s, which has a lower precedence. Prerelease channels can only be promoted in the order of `prerelease_channels`.", current, next),
+		)
+		return diags
+	}
+
+	s.setVersion(data, next)
+	data.History = appendAndTruncate(ctx, prior.History, s.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return diags
+}
+
+// describePrereleaseChannel returns the channel quoted, or "unset" for a release.
+func describePrereleaseChannel(channel string) string {
+	if channel == "" {
+		return "unset"
+	}
+	return strconv.Quote(channel)
+}
+
+// bumpFor returns how to change the version for the configured triggers and prerelease channel, or nil when it stays
+// the same. Component changes take precedence over channel changes, which take precedence over prerelease triggers.
+func (s SemanticVersionResource) bumpFor(prior semanticVersionModelV1, data semanticVersionModelV1, current semanticVersion) func(semanticVersion) semanticVersion {
+	channel := data.PrereleaseChannel.ValueString()
+	start := func(version semanticVersion) semanticVersion {
+		if channel != "" {
+			version.prerelease = []string{channel, "1"}
+		}
+		return version
+	}
+
+	major := !prior.MajorTriggers.Equal(data.MajorTriggers)
+	minor := !prior.MinorTriggers.Equal(data.MinorTriggers)
+	patch := !prior.PatchTriggers.Equal(data.PatchTriggers)
+	if current.major == 0 && data.InitialDevelopment.ValueBool() && major {
+		// Breaking changes during initial development increment the minor version, see https://semver.org/#spec-item-4.
+		major, minor = false, true
+	}
+
+	switch {
+	case current.major == 0 && !prior.StabilizeTriggers.Equal(data.StabilizeTriggers):
+		return func(v semanticVersion) semanticVersion { return start(semanticVersion{major: 1}) }
+	case major || minor || patch:
+		return func(v semanticVersion) semanticVersion { return start(s.increment(data, v, major, minor, patch)) }
+	case channel != current.channel() && channel == "":
+		// Promote the prerelease to the final release.
+		return func(v semanticVersion) semanticVersion {
+			return semanticVersion{major: v.major, minor: v.minor, patch: v.patch}
+		}
+	case channel != current.channel() && !current.isPrerelease():
+		// A release can't have prereleases of its own, so enter the channel for the next patch version.
+		return func(v semanticVersion) semanticVersion { return start(s.increment(data, v, false, false, true)) }
+	case channel != current.channel():
+		return func(v semanticVersion) semanticVersion {
+			if v.channel() == channel {
+				return s.nextPrerelease(v)
+			}
+			return start(semanticVersion{major: v.major, minor: v.minor, patch: v.patch})
+		}
+	case current.isPrerelease() && !prior.PrereleaseTriggers.Equal(data.PrereleaseTriggers):
+		return s.nextPrerelease
+	}
+	return nil
+}
+
+// increment adds the step of each changed component, resetting lower components according to reset_lower_components.
+// Unless simultaneous_changes is cumulative, only the highest changed component is incremented.
+func (s SemanticVersionResource) increment(data semanticVersionModelV1, v semanticVersion, major bool, minor bool, patch bool) semanticVersion {
+	cumulative := data.SimultaneousChanges.ValueString() == "cumulative"
+	resets := func(component string) bool {
+		reset, ok := data.ResetLowerComponents.Elements()[component].(types.Bool)
+		return !ok || reset.IsNull() || reset.ValueBool()
 	}
 
-	if !mapAttributeIsEqual(ctx, req, resp, "minor_triggers") {
-		var history []basetypes.ObjectValue
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("major_value"), &majorValue)...)
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("minor_value"), &minorValue)...)
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("history"), &history)...)
+	next := semanticVersion{major: v.major, minor: v.minor, patch: v.patch}
+	if major {
+		next.major += data.MajorStep.ValueInt64()
+		if resets("major") {
+			next.minor, next.patch = 0, 0
+		}
+		if !cumulative {
+			return next
+		}
+	}
+	if minor {
+		next.minor += data.MinorStep.ValueInt64()
+		if resets("minor") {
+			next.patch = 0
+		}
+		if !cumulative {
+			return next
+		}
+	}
+	if patch {
+		next.patch += data.PatchStep.ValueInt64()
+	}
+	return next
+}
 
-		minorValue = types.Int64Value(minorValue.ValueInt64() + 1)
-		patchValue = types.Int64Value(0)
-		value := types.StringValue(fmt.Sprintf("%d.%d.%d", majorValue.ValueInt64(), minorValue.ValueInt64(), patchValue.ValueInt64()))
-		history = appendAndTruncate(history, s.createHistoryEntry(value, majorValue, majorTriggers, minorValue, minorTriggers, patchValue, patchTriggers), maxHistory.ValueInt64())
+func (s SemanticVersionResource) nextPrerelease(version semanticVersion) semanticVersion {
+	version.prerelease = []string{version.channel(), strconv.FormatInt(version.prereleaseNumber()+1, 10)}
+	return version
+}
+
+// previousVersion returns the history entry produced for the configured triggers and prerelease channel when
+// reuse_previous_values is enabled. Entries of another channel are never reused, so promoting a prerelease produces a
+// version in the new channel even though the triggers are the same.
+func (s SemanticVersionResource) previousVersion(history types.List, data semanticVersionModelV1) (basetypes.ObjectValue, bool) {
+	if !data.ReusePreviousValues.ValueBool() {
+		return basetypes.ObjectValue{}, false
+	}
+	triggers := map[string]attr.Value{
+		"major_triggers":      data.MajorTriggers,
+		"minor_triggers":      data.MinorTriggers,
+		"patch_triggers":      data.PatchTriggers,
+		"prerelease_triggers": data.PrereleaseTriggers,
+		"stabilize_triggers":  data.StabilizeTriggers,
+	}
+	return findHistoryEntryFunc(history, func(entry basetypes.ObjectValue) bool {
+		for name, value := range triggers {
+			if !value.Equal(entry.Attributes()[name]) {
+				return false
+			}
+		}
+		version, err := parseSemanticVersion(entry.Attributes()["value"].(types.String).ValueString())
+		return err == nil && version.channel() == data.PrereleaseChannel.ValueString()
+	})
+}
 
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value"), value)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("major_value"), majorValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("minor_value"), minorValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("patch_value"), patchValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("history"), history)...)
+func (s SemanticVersionResource) setVersion(data *semanticVersionModelV1, version semanticVersion) {
+	version.build = nil
+	data.MajorValue = types.Int64Value(version.major)
+	data.MinorValue = types.Int64Value(version.minor)
+	data.PatchValue = types.Int64Value(version.patch)
+	data.Value = types.StringValue(version.String())
+	data.PrereleaseValue = types.StringNull()
+	if version.isPrerelease() {
+		data.PrereleaseValue = types.StringValue(strings.Join(version.prerelease, "."))
+	}
+
+	if data.BuildMetadata.IsUnknown() {
+		data.ValueWithMetadata = types.StringUnknown()
+		data.FormattedValue = types.StringUnknown()
+		data.PresetValues = types.MapUnknown(types.StringType)
 		return
 	}
+	if metadata := data.BuildMetadata.ValueString(); metadata != "" {
+		version.build = strings.Split(metadata, ".")
+	}
+	data.ValueWithMetadata = types.StringValue(version.String())
 
-	if !mapAttributeIsEqual(ctx, req, resp, "patch_triggers") {
-		var history []basetypes.ObjectValue
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("major_value"), &majorValue)...)
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("minor_value"), &minorValue)...)
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("patch_value"), &patchValue)...)
-		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("history"), &history)...)
+	data.FormattedValue = types.StringUnknown()
+	if !data.Format.IsUnknown() {
+		data.FormattedValue = types.StringValue(version.format(data.Format.ValueString()))
+	}
+	presets := make(map[string]attr.Value, len(versionFormatPresets))
+	for name := range versionFormatPresets {
+		presets[name] = types.StringValue(version.format(name))
+	}
+	data.PresetValues = types.MapValueMust(types.StringType, presets)
+}
 
-		patchValue = types.Int64Value(patchValue.ValueInt64() + 1)
-		value := types.StringValue(fmt.Sprintf("%d.%d.%d", majorValue.ValueInt64(), minorValue.ValueInt64(), patchValue.ValueInt64()))
-		history = appendAndTruncate(history, s.createHistoryEntry(value, majorValue, majorTriggers, minorValue, minorTriggers, patchValue, patchTriggers), maxHistory.ValueInt64())
+func (s SemanticVersionResource) setUnknownVersion(data *semanticVersionModelV1) {
+	data.MajorValue = types.Int64Unknown()
+	data.MinorValue = types.Int64Unknown()
+	data.PatchValue = types.Int64Unknown()
+	data.Value = types.StringUnknown()
+	data.ValueWithMetadata = types.StringUnknown()
+	data.FormattedValue = types.StringUnknown()
+	data.PresetValues = types.MapUnknown(types.StringType)
+	data.PrereleaseValue = types.StringUnknown()
+}
 
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value"), value)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("major_value"), majorValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("minor_value"), minorValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("patch_value"), patchValue)...)
-		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("history"), history)...)
+func (s SemanticVersionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := parseImportId(req.ID, "value", "value", "major_initial_value", "minor_initial_value", "patch_initial_value", "max_history")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: %s. Expected a version such as `3.7.2`, or `value=3.7.2,major_initial_value=0`.", req.ID, err))
+		return
 	}
+
+	data := semanticVersionModelV1{
+		Id:                   types.StringValue(uuid.New().String()),
+		MaxHistory:           types.Int64Value(defaultMaxHistory),
+		MajorInitialValue:    types.Int64Value(1),
+		MinorInitialValue:    types.Int64Value(0),
+		PatchInitialValue:    types.Int64Value(0),
+		MajorTriggers:        types.MapNull(types.StringType),
+		MinorTriggers:        types.MapNull(types.StringType),
+		PatchTriggers:        types.MapNull(types.StringType),
+		PrereleaseTriggers:   types.MapNull(types.StringType),
+		StabilizeTriggers:    types.MapNull(types.StringType),
+		InitialDevelopment:   types.BoolValue(false),
+		MajorStep:            types.Int64Value(1),
+		MinorStep:            types.Int64Value(1),
+		PatchStep:            types.Int64Value(1),
+		ResetLowerComponents: types.MapNull(types.BoolType),
+		SimultaneousChanges:  types.StringValue("highest_wins"),
+		PrereleaseChannel:    types.StringNull(),
+		PrereleaseChannels:   defaultPrereleaseChannels,
+		BuildMetadata:        types.StringNull(),
+		Format:               types.StringValue("semver"),
+		ReusePreviousValues:  types.BoolValue(false),
+	}
+	for attribute, raw := range values {
+		if attribute == "value" {
+			version, err := parseSemanticVersion(raw)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Import Identifier", fmt.Sprintf("The version %q is not in `<major>.<minor>.<patch>[-<prerelease>][+<build>]` form.", raw))
+				continue
+			}
+			if len(version.build) > 0 {
+				data.BuildMetadata = types.StringValue(strings.Join(version.build, "."))
+			}
+			s.setVersion(&data, version)
+			if version.isPrerelease() {
+				data.PrereleaseChannel = types.StringValue(version.channel())
+			}
+			continue
+		}
+
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Import Identifier", fmt.Sprintf("The value %q for %s is not a whole number.", raw, attribute))
+			continue
+		}
+		if number < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Import Identifier", fmt.Sprintf("The value %q for %s must not be negative.", raw, attribute))
+			continue
+		}
+		switch attribute {
+		case "major_initial_value":
+			data.MajorInitialValue = types.Int64Value(number)
+		case "minor_initial_value":
+			data.MinorInitialValue = types.Int64Value(number)
+		case "patch_initial_value":
+			data.PatchInitialValue = types.Int64Value(number)
+		case "max_history":
+			data.MaxHistory = types.Int64Value(number)
+		}
+	}
+	if data.MaxHistory.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_history"), "Invalid Import Identifier", "The max_history must be at least 1.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.History = appendAndTruncate(ctx, types.ListNull(semanticVersionHistoryEntryType), s.createHistoryEntry(data), data.MaxHistory.ValueInt64())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
 }
 
-func (s SemanticVersionResource) createHistoryEntry(value types.String, majorValue types.Int64, majorTriggers types.Map, minorValue types.Int64, minorTriggers types.Map, patchValue types.Int64, patchTriggers types.Map) basetypes.ObjectValue {
+var semanticVersionHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":               types.StringType,
+		"major_value":         types.Int64Type,
+		"minor_value":         types.Int64Type,
+		"patch_value":         types.Int64Type,
+		"major_triggers":      types.MapType{ElemType: types.StringType},
+		"minor_triggers":      types.MapType{ElemType: types.StringType},
+		"patch_triggers":      types.MapType{ElemType: types.StringType},
+		"prerelease_value":    types.StringType,
+		"prerelease_triggers": types.MapType{ElemType: types.StringType},
+		"stabilize_triggers":  types.MapType{ElemType: types.StringType},
+		"build_metadata":      types.StringType,
+		"formatted_value":     types.StringType,
+	},
+}
+
+func (s SemanticVersionResource) createHistoryEntry(data semanticVersionModelV1) basetypes.ObjectValue {
 	return types.ObjectValueMust(
-		map[string]attr.Type{
-			"value":          types.StringType,
-			"major_value":    types.Int64Type,
-			"minor_value":    types.Int64Type,
-			"patch_value":    types.Int64Type,
-			"major_triggers": types.MapType{ElemType: types.StringType},
-			"minor_triggers": types.MapType{ElemType: types.StringType},
-			"patch_triggers": types.MapType{ElemType: types.StringType},
-		},
+		semanticVersionHistoryEntryType.AttrTypes,
 		map[string]attr.Value{
-			"value":          value,
-			"major_value":    majorValue,
-			"minor_value":    minorValue,
-			"patch_value":    patchValue,
-			"major_triggers": majorTriggers,
-			"minor_triggers": minorTriggers,
-			"patch_triggers": patchTriggers,
+			"value":               data.Value,
+			"major_value":         data.MajorValue,
+			"minor_value":         data.MinorValue,
+			"patch_value":         data.PatchValue,
+			"major_triggers":      data.MajorTriggers,
+			"minor_triggers":      data.MinorTriggers,
+			"patch_triggers":      data.PatchTriggers,
+			"prerelease_value":    data.PrereleaseValue,
+			"prerelease_triggers": data.PrereleaseTriggers,
+			"stabilize_triggers":  data.StabilizeTriggers,
+			"build_metadata":      data.BuildMetadata,
+			"formatted_value":     data.FormattedValue,
 		},
 	)
 }
 
 type semanticVersionModelV1 struct {
-	Id                types.String            `tfsdk:"id"`
-	MajorValue        types.Int64             `tfsdk:"major_value"`
-	MinorValue        types.Int64             `tfsdk:"minor_value"`
-	PatchValue        types.Int64             `tfsdk:"patch_value"`
-	Value             types.String            `tfsdk:"value"`
-	MaxHistory        types.Int64             `tfsdk:"max_history"`
-	History           []basetypes.ObjectValue `tfsdk:"history"`
-	MajorInitialValue types.Int64             `tfsdk:"major_initial_value"`
-	MinorInitialValue types.Int64             `tfsdk:"minor_initial_value"`
-	PatchInitialValue types.Int64             `tfsdk:"patch_initial_value"`
-	MajorTriggers     types.Map               `tfsdk:"major_triggers"`
-	MinorTriggers     types.Map               `tfsdk:"minor_triggers"`
-	PatchTriggers     types.Map               `tfsdk:"patch_triggers"`
+	Id                   types.String `tfsdk:"id"`
+	MajorValue           types.Int64  `tfsdk:"major_value"`
+	MinorValue           types.Int64  `tfsdk:"minor_value"`
+	PatchValue           types.Int64  `tfsdk:"patch_value"`
+	Value                types.String `tfsdk:"value"`
+	MaxHistory           types.Int64  `tfsdk:"max_history"`
+	History              types.List   `tfsdk:"history"`
+	MajorInitialValue    types.Int64  `tfsdk:"major_initial_value"`
+	MinorInitialValue    types.Int64  `tfsdk:"minor_initial_value"`
+	PatchInitialValue    types.Int64  `tfsdk:"patch_initial_value"`
+	MajorTriggers        types.Map    `tfsdk:"major_triggers"`
+	MinorTriggers        types.Map    `tfsdk:"minor_triggers"`
+	PatchTriggers        types.Map    `tfsdk:"patch_triggers"`
+	PrereleaseValue      types.String `tfsdk:"prerelease_value"`
+	PrereleaseTriggers   types.Map    `tfsdk:"prerelease_triggers"`
+	StabilizeTriggers    types.Map    `tfsdk:"stabilize_triggers"`
+	InitialDevelopment   types.Bool   `tfsdk:"initial_development"`
+	MajorStep            types.Int64  `tfsdk:"major_step"`
+	MinorStep            types.Int64  `tfsdk:"minor_step"`
+	PatchStep            types.Int64  `tfsdk:"patch_step"`
+	ResetLowerComponents types.Map    `tfsdk:"reset_lower_components"`
+	SimultaneousChanges  types.String `tfsdk:"simultaneous_changes"`
+	PrereleaseChannel    types.String `tfsdk:"prerelease_channel"`
+	PrereleaseChannels   types.List   `tfsdk:"prerelease_channels"`
+	BuildMetadata        types.String `tfsdk:"build_metadata"`
+	ValueWithMetadata    types.String `tfsdk:"value_with_metadata"`
+	Format               types.String `tfsdk:"format"`
+	FormattedValue       types.String `tfsdk:"formatted_value"`
+	PresetValues         types.Map    `tfsdk:"preset_values"`
+	ReusePreviousValues  types.Bool   `tfsdk:"reuse_previous_values"`
 }
@@ -2,6 +2,9 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"regexp"
 	"testing"
 )
 
@@ -117,3 +120,606 @@ func TestAccSemanticVersionResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSemanticVersionResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: step1Semantic(),
+			},
+			// Import an existing version
+			{
+				Config:             step1Semantic(),
+				ResourceName:       "counter_semantic_version.this",
+				ImportState:        true,
+				ImportStateId:      "3.7.2",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without incrementing
+			{
+				Config: step1Semantic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "3.7.2"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.#", "1"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.0.patch_triggers.hash", "potatoes"),
+				),
+			},
+			{
+				Config: step2Semantic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "3.7.3"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.1.value", "3.7.3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSemanticVersionResourceImportPrerelease(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: reusePrereleaseStep("alpha"),
+			},
+			{
+				Config:             reusePrereleaseStep("alpha"),
+				ResourceName:       "counter_semantic_version.this",
+				ImportState:        true,
+				ImportStateId:      "2.0.0-alpha.1",
+				ImportStatePersist: true,
+			},
+			// Adopting the prerelease without its channel would promote it on the next plan
+			{
+				Config:      reusePrereleaseStep(""),
+				ExpectError: regexp.MustCompile(`Imported Prerelease Channel Mismatch`),
+			},
+			{
+				Config: reusePrereleaseStep("alpha"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "2.0.0-alpha.1"),
+				),
+			},
+			{
+				Config: reusePrereleaseStep("alpha"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccSemanticVersionResourceValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource counter_semantic_version this {
+						minor_initial_value = -1
+						patch_triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`minor_initial_value value must be at least 0`),
+			},
+			{
+				Config: `
+					resource counter_semantic_version this {
+						max_history = 0
+						patch_triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`max_history value must be at least 1`),
+			},
+		},
+	})
+}
+
+func unknownTriggersSemanticStep(input string, replace string) string {
+	return `
+		resource terraform_data source {
+			input            = "` + input + `"
+			triggers_replace = ["` + replace + `"]
+		}
+
+		resource counter_semantic_version this {
+			minor_triggers = {
+				hash = terraform_data.source.output
+			}
+			patch_triggers = {
+				hash = "potatoes"
+			}
+		}
+	`
+}
+
+func TestAccSemanticVersionResourceUnknownTriggers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: unknownTriggersSemanticStep("potatoes", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+				),
+			},
+			// Replacing the source makes the value of a trigger unknown during plan, so the plan can't tell whether the
+			// version changes. The trigger resolves to the same value when applied.
+			{
+				Config: unknownTriggersSemanticStep("potatoes", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("counter_semantic_version.this", tfjsonpath.New("value")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.#", "1"),
+				),
+			},
+			// Unknown triggers which resolve to a different value increment the version
+			{
+				Config: unknownTriggersSemanticStep("eggs", "3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.1.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func reuseSemanticStep(hash string) string {
+	return `
+		resource counter_semantic_version this {
+			reuse_previous_values = true
+			patch_triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccSemanticVersionResourceReusePreviousValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: reuseSemanticStep("potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+				),
+			},
+			{
+				Config: reuseSemanticStep("eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.1"),
+				),
+			},
+			// Reverting the triggers reuses the version produced for them
+			{
+				Config: reuseSemanticStep("potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.#", "3"),
+				),
+			},
+			// New triggers skip versions which are already taken
+			{
+				Config: reuseSemanticStep("bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.2"),
+				),
+			},
+		},
+	})
+}
+
+func reusePrereleaseStep(channel string) string {
+	channelAttribute := ""
+	if channel != "" {
+		channelAttribute = `prerelease_channel = "` + channel + `"`
+	}
+	return `
+		resource counter_semantic_version this {
+			reuse_previous_values = true
+			` + channelAttribute + `
+			patch_triggers = {
+				hash = "potatoes"
+			}
+		}
+	`
+}
+
+func TestAccSemanticVersionResourceReusePreviousValuesPromotion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: reusePrereleaseStep("alpha"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0-alpha.1"),
+				),
+			},
+			// Promoting with the same triggers doesn't reuse the version of the earlier channel
+			{
+				Config: reusePrereleaseStep("beta"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0-beta.1"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.#", "2"),
+				),
+			},
+			{
+				Config: reusePrereleaseStep(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func prereleaseStep(channel string, prereleaseHash string) string {
+	channelAttribute := ""
+	if channel != "" {
+		channelAttribute = `prerelease_channel = "` + channel + `"`
+	}
+	return `
+		resource counter_semantic_version this {
+			` + channelAttribute + `
+			major_triggers = {
+				hash = "potatoes"
+			}
+			prerelease_triggers = {
+				hash = "` + prereleaseHash + `"
+			}
+		}
+	`
+}
+
+func TestAccSemanticVersionResourcePrerelease(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test initial prerelease
+			{
+				Config: prereleaseStep("alpha", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0-alpha.1"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "prerelease_value", "alpha.1"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.0.prerelease_value", "alpha.1"),
+				),
+			},
+			// Test prerelease number increment
+			{
+				Config: prereleaseStep("alpha", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0-alpha.2"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.1.prerelease_triggers.hash", "bacon"),
+				),
+			},
+			// Test promotion to the next channel
+			{
+				Config: prereleaseStep("rc", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0-rc.1"),
+				),
+			},
+			// Test moving back to an earlier channel
+			{
+				Config:      prereleaseStep("beta", "bacon"),
+				ExpectError: regexp.MustCompile(`Version Would Go Backwards`),
+			},
+			// Test promotion to the final release
+			{
+				Config: prereleaseStep("", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+					resource.TestCheckNoResourceAttr("counter_semantic_version.this", "prerelease_value"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.#", "4"),
+				),
+			},
+			// Test entering a channel from a release
+			{
+				Config: prereleaseStep("beta", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.1-beta.1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSemanticVersionResourcePrereleaseValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource counter_semantic_version this {
+						prerelease_channel = "gamma"
+						patch_triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Unknown Prerelease Channel`),
+			},
+			{
+				Config: `
+					resource counter_semantic_version this {
+						prerelease_channels = ["rc", "beta"]
+						patch_triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Prerelease Channel Order`),
+			},
+		},
+	})
+}
+
+func buildMetadataStep(hash string, metadata string) string {
+	return `
+		resource counter_semantic_version this {
+			build_metadata = "` + metadata + `"
+			patch_triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccSemanticVersionResourceBuildMetadata(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test initial metadata
+			{
+				Config: buildMetadataStep("eggs", "sha.abc123"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value_with_metadata", "1.0.0+sha.abc123"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.0.build_metadata", "sha.abc123"),
+				),
+			},
+			// Test metadata change does not increment
+			{
+				Config: buildMetadataStep("eggs", "sha.def456"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value_with_metadata", "1.0.0+sha.def456"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.#", "1"),
+				),
+			},
+			// Test metadata is recorded with the next version
+			{
+				Config: buildMetadataStep("bacon", "sha.789abc"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.1"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value_with_metadata", "1.0.1+sha.789abc"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.1.build_metadata", "sha.789abc"),
+				),
+			},
+			// Test invalid metadata
+			{
+				Config:      buildMetadataStep("bacon", "sha..789abc"),
+				ExpectError: regexp.MustCompile(`build identifiers`),
+			},
+		},
+	})
+}
+
+func formatStep(hash string, format string) string {
+	return `
+		resource counter_semantic_version this {
+			build_metadata = "sha.abc123"
+			format = "` + format + `"
+			patch_triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccSemanticVersionResourceFormat(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test template
+			{
+				Config: formatStep("eggs", "v{major}.{minor}.{patch}{-prerelease}"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "formatted_value", "v1.0.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.0.formatted_value", "v1.0.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "preset_values.semver", "1.0.0+sha.abc123"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "preset_values.oci", "1.0.0_sha.abc123"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "preset_values.npm", "1.0.0"),
+				),
+			},
+			// Test format change does not increment
+			{
+				Config: formatStep("eggs", "oci"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "formatted_value", "1.0.0_sha.abc123"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.#", "1"),
+				),
+			},
+			// Test format is recorded with the next version
+			{
+				Config: formatStep("bacon", "{major}.{minor}"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "formatted_value", "1.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.1.formatted_value", "1.0"),
+				),
+			},
+			// Test unsupported placeholder
+			{
+				Config:      formatStep("bacon", "{major}.{revision}"),
+				ExpectError: regexp.MustCompile(`Invalid Version Format`),
+			},
+		},
+	})
+}
+
+func initialDevelopmentStep(majorHash string, stable string) string {
+	return `
+		resource counter_semantic_version this {
+			initial_development = true
+			major_initial_value = 0
+			minor_initial_value = 1
+			major_triggers = {
+				hash = "` + majorHash + `"
+			}
+			stabilize_triggers = {
+				stable = "` + stable + `"
+			}
+		}
+	`
+}
+
+func TestAccSemanticVersionResourceInitialDevelopment(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test initial version
+			{
+				Config: initialDevelopmentStep("eggs", "no"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "0.1.0"),
+				),
+			},
+			// Test major trigger increments minor version
+			{
+				Config: initialDevelopmentStep("bacon", "no"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "0.2.0"),
+				),
+			},
+			// Test stabilize
+			{
+				Config: initialDevelopmentStep("bacon", "yes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "history.2.stabilize_triggers.stable", "yes"),
+				),
+			},
+			// Test major trigger increments major version once stable
+			{
+				Config: initialDevelopmentStep("ham", "yes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "2.0.0"),
+				),
+			},
+		},
+	})
+}
+
+func cascadeStep(minorHash string, patchHash string) string {
+	return `
+		resource counter_semantic_version this {
+			patch_step = 10
+			simultaneous_changes = "cumulative"
+			reset_lower_components = {
+				minor = false
+			}
+			minor_triggers = {
+				hash = "` + minorHash + `"
+			}
+			patch_triggers = {
+				hash = "` + patchHash + `"
+			}
+		}
+	`
+}
+
+func TestAccSemanticVersionResourceCascade(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test initial version
+			{
+				Config: cascadeStep("eggs", "eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.0"),
+				),
+			},
+			// Test patch step
+			{
+				Config: cascadeStep("eggs", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.0.10"),
+				),
+			},
+			// Test minor increment keeps the patch number
+			{
+				Config: cascadeStep("bacon", "bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.1.10"),
+				),
+			},
+			// Test cumulative changes
+			{
+				Config: cascadeStep("ham", "ham"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "1.2.20"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSemanticVersionResourceUnknownInitialValue(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The initial version isn't known until the source is created
+			{
+				Config: `
+					resource terraform_data source {
+						input = 3
+					}
+
+					resource counter_semantic_version this {
+						major_initial_value = terraform_data.source.output
+						patch_triggers = {
+							hash = "potatoes"
+						}
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("counter_semantic_version.this", tfjsonpath.New("value")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_semantic_version.this", "value", "3.0.0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ resource.ResourceWithUpgradeState = &SemanticVersionResource{}
+
+// semanticVersionSchemaVersion is the current version of the counter_semantic_version schema. Bump it, freeze the
+// previous schema and model below and add an upgrader from the previous version whenever the stored layout changes.
+const semanticVersionSchemaVersion = 1
+
+func (s SemanticVersionResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := semanticVersionSchemaV0()
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior semanticVersionModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				upgraded := s.upgradeStateV0(prior)
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
+	}
+}
+
+// upgradeStateV0 converts state written before the schema was versioned. Such state may lack history or hold more
+// entries than max_history allows, so the history is seeded from the current version and truncated.
+func (s SemanticVersionResource) upgradeStateV0(prior semanticVersionModelV0) semanticVersionModelV1 {
+	maxHistory := prior.MaxHistory
+	if maxHistory.IsNull() || maxHistory.ValueInt64() < 1 {
+		maxHistory = types.Int64Value(defaultMaxHistory)
+	}
+	history := make([]attr.Value, 0, len(prior.History))
+	for _, entry := range prior.History {
+		history = append(history, s.upgradeHistoryEntryV0(entry))
+	}
+
+	upgraded := semanticVersionModelV1{
+		Id:                   prior.Id,
+		MajorValue:           prior.MajorValue,
+		MinorValue:           prior.MinorValue,
+		PatchValue:           prior.PatchValue,
+		Value:                prior.Value,
+		MaxHistory:           maxHistory,
+		MajorInitialValue:    prior.MajorInitialValue,
+		MinorInitialValue:    prior.MinorInitialValue,
+		PatchInitialValue:    prior.PatchInitialValue,
+		MajorTriggers:        prior.MajorTriggers,
+		MinorTriggers:        prior.MinorTriggers,
+		PatchTriggers:        prior.PatchTriggers,
+		PrereleaseValue:      types.StringNull(),
+		PrereleaseTriggers:   types.MapNull(types.StringType),
+		StabilizeTriggers:    types.MapNull(types.StringType),
+		InitialDevelopment:   types.BoolValue(false),
+		MajorStep:            types.Int64Value(1),
+		MinorStep:            types.Int64Value(1),
+		PatchStep:            types.Int64Value(1),
+		ResetLowerComponents: types.MapNull(types.BoolType),
+		SimultaneousChanges:  types.StringValue("highest_wins"),
+		PrereleaseChannel:    types.StringNull(),
+		PrereleaseChannels:   defaultPrereleaseChannels,
+		BuildMetadata:        types.StringNull(),
+		Format:               types.StringValue("semver"),
+		ReusePreviousValues:  types.BoolValue(false),
+	}
+	// Version 0 only produced release versions, so the derived attributes can be rendered from the components.
+	s.setVersion(&upgraded, semanticVersion{
+		major: prior.MajorValue.ValueInt64(),
+		minor: prior.MinorValue.ValueInt64(),
+		patch: prior.PatchValue.ValueInt64(),
+	})
+	if len(history) == 0 {
+		history = append(history, s.createHistoryEntry(upgraded))
+	}
+	upgraded.History = types.ListValueMust(semanticVersionHistoryEntryType, truncate(history, maxHistory.ValueInt64()))
+	return upgraded
+}
+
+// upgradeHistoryEntryV0 adds the history attributes which were introduced after version 0 of the schema.
+func (s SemanticVersionResource) upgradeHistoryEntryV0(entry basetypes.ObjectValue) basetypes.ObjectValue {
+	attributes := entry.Attributes()
+	attributes["prerelease_value"] = types.StringNull()
+	attributes["prerelease_triggers"] = types.MapNull(types.StringType)
+	attributes["stabilize_triggers"] = types.MapNull(types.StringType)
+	attributes["build_metadata"] = types.StringNull()
+	attributes["formatted_value"] = attributes["value"]
+	return types.ObjectValueMust(semanticVersionHistoryEntryType.AttrTypes, attributes)
+}
+
+func semanticVersionSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"major_value": schema.Int64Attribute{
+				Computed: true,
+			},
+			"minor_value": schema.Int64Attribute{
+				Computed: true,
+			},
+			"patch_value": schema.Int64Attribute{
+				Computed: true,
+			},
+			"value": schema.StringAttribute{
+				Computed: true,
+			},
+			"max_history": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+			},
+			"history": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+						"major_value": schema.Int64Attribute{
+							Computed: true,
+						},
+						"minor_value": schema.Int64Attribute{
+							Computed: true,
+						},
+						"patch_value": schema.Int64Attribute{
+							Computed: true,
+						},
+						"major_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"minor_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"patch_triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"major_initial_value": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+			},
+			"minor_initial_value": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+			},
+			"patch_initial_value": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+			},
+			"major_triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"minor_triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"patch_triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+type semanticVersionModelV0 struct {
+	Id                types.String            `tfsdk:"id"`
+	MajorValue        types.Int64             `tfsdk:"major_value"`
+	MinorValue        types.Int64             `tfsdk:"minor_value"`
+	PatchValue        types.Int64             `tfsdk:"patch_value"`
+	Value             types.String            `tfsdk:"value"`
+	MaxHistory        types.Int64             `tfsdk:"max_history"`
+	History           []basetypes.ObjectValue `tfsdk:"history"`
+	MajorInitialValue types.Int64             `tfsdk:"major_initial_value"`
+	MinorInitialValue types.Int64             `tfsdk:"minor_initial_value"`
+	PatchInitialValue types.Int64             `tfsdk:"patch_initial_value"`
+	MajorTriggers     types.Map               `tfsdk:"major_triggers"`
+	MinorTriggers     types.Map               `tfsdk:"minor_triggers"`
+	PatchTriggers     types.Map               `tfsdk:"patch_triggers"`
+}
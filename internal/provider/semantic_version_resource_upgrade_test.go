@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSemanticVersionResourceUpgradeStateV0(t *testing.T) {
+	state := testUpgradeResourceState(t, "counter_semantic_version", 0, `{
+		"id": "c0ffee",
+		"major_value": 1,
+		"minor_value": 1,
+		"patch_value": 0,
+		"value": "1.1.0",
+		"max_history": 1000,
+		"major_initial_value": 1,
+		"minor_initial_value": 0,
+		"patch_initial_value": 0,
+		"major_triggers": null,
+		"minor_triggers": {"hash": "potatoes"},
+		"patch_triggers": {"hash": "bacon"},
+		"history": [
+			{"value": "1.0.0", "major_value": 1, "minor_value": 0, "patch_value": 0, "major_triggers": null, "minor_triggers": null, "patch_triggers": {"hash": "potatoes"}},
+			{"value": "1.1.0", "major_value": 1, "minor_value": 1, "patch_value": 0, "major_triggers": null, "minor_triggers": {"hash": "potatoes"}, "patch_triggers": {"hash": "bacon"}}
+		]
+	}`)
+
+	if value := testStateAttribute(t, state, tftypes.AttributeName("value")); !value.Equal(tftypes.NewValue(tftypes.String, "1.1.0")) {
+		t.Errorf("expected value 1.1.0, got %s", value)
+	}
+	if value := testStateAttribute(t, state, tftypes.AttributeName("history"), tftypes.ElementKeyInt(1), tftypes.AttributeName("minor_triggers"), tftypes.ElementKeyString("hash")); !value.Equal(tftypes.NewValue(tftypes.String, "potatoes")) {
+		t.Errorf("expected history to be preserved, got %s", value)
+	}
+}
+
+func TestSemanticVersionResourceUpgradeStateV0WithoutHistory(t *testing.T) {
+	state := testUpgradeResourceState(t, "counter_semantic_version", 0, `{
+		"id": "c0ffee",
+		"major_value": 2,
+		"minor_value": 3,
+		"patch_value": 4,
+		"value": "2.3.4",
+		"max_history": null,
+		"major_initial_value": 1,
+		"minor_initial_value": 0,
+		"patch_initial_value": 0,
+		"major_triggers": null,
+		"minor_triggers": null,
+		"patch_triggers": {"hash": "bacon"},
+		"history": []
+	}`)
+
+	if value := testStateAttribute(t, state, tftypes.AttributeName("history"), tftypes.ElementKeyInt(0), tftypes.AttributeName("value")); !value.Equal(tftypes.NewValue(tftypes.String, "2.3.4")) {
+		t.Errorf("expected seeded history value 2.3.4, got %s", value)
+	}
+	if value := testStateAttribute(t, state, tftypes.AttributeName("max_history")); value.IsNull() {
+		t.Errorf("expected max_history to be defaulted")
+	}
+}
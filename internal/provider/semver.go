@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// semanticVersionPattern matches a SemVer 2.0 version string, see https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string.
+var semanticVersionPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// prereleaseChannelPattern matches an alphanumeric prerelease identifier which can be used as a channel name.
+var prereleaseChannelPattern = regexp.MustCompile(`^[0-9A-Za-z-]*[A-Za-z-][0-9A-Za-z-]*$`)
+
+// buildMetadataPattern matches dot separated SemVer build identifiers.
+var buildMetadataPattern = regexp.MustCompile(`^[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*$`)
+
+// versionFormatPresets are the named formats accepted in place of a template, each rendering only the characters the
+// ecosystem accepts in a version.
+var versionFormatPresets = map[string]string{
+	"semver": "{major}.{minor}.{patch}{-prerelease}{+build}",
+	"npm":    "{major}.{minor}.{patch}{-prerelease}",
+	"nuget":  "{major}.{minor}.{patch}{-prerelease}",
+	"helm":   "{major}.{minor}.{patch}{-prerelease}{+build}",
+	"oci":    "{major}.{minor}.{patch}{-prerelease}{_build}",
+}
+
+// versionFormatPlaceholder matches a `{name}` template placeholder, optionally with a separator such as `{-prerelease}`
+// which is only rendered when the component is present.
+var versionFormatPlaceholder = regexp.MustCompile(`\{([^0-9A-Za-z{}]?)(major|minor|patch|prerelease|build)\}`)
+
+// versionFormatBraces matches anything in braces, so unsupported placeholders can be reported.
+var versionFormatBraces = regexp.MustCompile(`\{[^{}]*\}`)
+
+type semanticVersion struct {
+	major      int64
+	minor      int64
+	patch      int64
+	prerelease []string
+	build      []string
+}
+
+func parseSemanticVersion(value string) (semanticVersion, error) {
+	matches := semanticVersionPattern.FindStringSubmatch(value)
+	if matches == nil {
+		return semanticVersion{}, fmt.Errorf("%q is not a valid semantic version", value)
+	}
+
+	var version semanticVersion
+	var err error
+	for i, component := range []*int64{&version.major, &version.minor, &version.patch} {
+		if *component, err = strconv.ParseInt(matches[i+1], 10, 64); err != nil {
+			return semanticVersion{}, fmt.Errorf("%q is not a valid semantic version: %w", value, err)
+		}
+	}
+	if matches[4] != "" {
+		version.prerelease = strings.Split(matches[4], ".")
+	}
+	if matches[5] != "" {
+		version.build = strings.Split(matches[5], ".")
+	}
+	return version, nil
+}
+
+func (v semanticVersion) String() string {
+	value := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if len(v.prerelease) > 0 {
+		value += "-" + strings.Join(v.prerelease, ".")
+	}
+	if len(v.build) > 0 {
+		value += "+" + strings.Join(v.build, ".")
+	}
+	return value
+}
+
+// format renders the version with a template or the name of one of the versionFormatPresets.
+func (v semanticVersion) format(format string) string {
+	if preset, ok := versionFormatPresets[format]; ok {
+		format = preset
+	} else if format == "" {
+		format = versionFormatPresets["semver"]
+	}
+	return versionFormatPlaceholder.ReplaceAllStringFunc(format, func(placeholder string) string {
+		match := versionFormatPlaceholder.FindStringSubmatch(placeholder)
+		var value string
+		switch match[2] {
+		case "major":
+			value = strconv.FormatInt(v.major, 10)
+		case "minor":
+			value = strconv.FormatInt(v.minor, 10)
+		case "patch":
+			value = strconv.FormatInt(v.patch, 10)
+		case "prerelease":
+			value = strings.Join(v.prerelease, ".")
+		case "build":
+			value = strings.Join(v.build, ".")
+		}
+		if value == "" {
+			return ""
+		}
+		return match[1] + value
+	})
+}
+
+// validateVersionFormat checks that a format is either a preset name or a template using only supported placeholders.
+func validateVersionFormat(format string) error {
+	if _, ok := versionFormatPresets[format]; ok {
+		return nil
+	}
+	placeholders := versionFormatBraces.FindAllString(format, -1)
+	if len(placeholders) == 0 {
+		return fmt.Errorf("%q is neither a preset nor a template with any placeholders", format)
+	}
+	for _, placeholder := range placeholders {
+		if !versionFormatPlaceholder.MatchString(placeholder) {
+			return fmt.Errorf("%q is not a supported placeholder", placeholder)
+		}
+	}
+	return nil
+}
+
+// isPrerelease reports whether the version has prerelease identifiers.
+func (v semanticVersion) isPrerelease() bool {
+	return len(v.prerelease) > 0
+}
+
+// channel returns the first prerelease identifier, which names the prerelease channel, or "" for a release.
+func (v semanticVersion) channel() string {
+	if len(v.prerelease) == 0 {
+		return ""
+	}
+	return v.prerelease[0]
+}
+
+// prereleaseNumber returns the numeric identifier following the prerelease channel, or 0 when there is none.
+func (v semanticVersion) prereleaseNumber() int64 {
+	if len(v.prerelease) < 2 {
+		return 0
+	}
+	number, err := strconv.ParseInt(v.prerelease[len(v.prerelease)-1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// compare returns -1, 0 or 1 according to SemVer precedence. Build metadata is ignored.
+func (v semanticVersion) compare(other semanticVersion) int {
+	for _, pair := range [][2]int64{{v.major, other.major}, {v.minor, other.minor}, {v.patch, other.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A release has higher precedence than any of its prereleases.
+	switch {
+	case len(v.prerelease) == 0 && len(other.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(other.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		if result := comparePrereleaseIdentifiers(v.prerelease[i], other.prerelease[i]); result != 0 {
+			return result
+		}
+	}
+	switch {
+	case len(v.prerelease) < len(other.prerelease):
+		return -1
+	case len(v.prerelease) > len(other.prerelease):
+		return 1
+	}
+	return 0
+}
+
+// comparePrereleaseIdentifiers compares numeric identifiers numerically and others lexically in ASCII order. Numeric
+// identifiers always have lower precedence than alphanumeric identifiers.
+func comparePrereleaseIdentifiers(a string, b string) int {
+	aNumber, aErr := strconv.ParseUint(a, 10, 64)
+	bNumber, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case aNumber < bNumber:
+			return -1
+		case aNumber > bNumber:
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// semanticVersionConstraint is a single comparison such as `>= 1.2.0` or `~> 1.2`.
+type semanticVersionConstraint struct {
+	operator string
+	version  semanticVersion
+	// precision is the number of version components given, which determines the upper bound of `~>`.
+	precision int
+}
+
+// partialVersionPattern matches the leading components of a version, such as `1` or `1.2`.
+var partialVersionPattern = regexp.MustCompile(`^(0|[1-9]\d*)(?:\.(0|[1-9]\d*))?$`)
+
+// constraintOperators are checked in order, so longer operators must come before their prefixes.
+var constraintOperators = []string{"~>", ">=", "<=", "!=", ">", "<", "="}
+
+// parseSemanticVersionConstraints parses comma separated constraints, all of which must be satisfied, using the
+// operators `=`, `!=`, `>`, `>=`, `<`, `<=` and `~>`. Versions may omit the minor and patch components.
+func parseSemanticVersionConstraints(value string) ([]semanticVersionConstraint, error) {
+	var constraints []semanticVersionConstraint
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		constraint := semanticVersionConstraint{operator: "=", precision: 3}
+		for _, operator := range constraintOperators {
+			if strings.HasPrefix(part, operator) {
+				constraint.operator = operator
+				part = strings.TrimSpace(strings.TrimPrefix(part, operator))
+				break
+			}
+		}
+
+		var err error
+		if constraint.version, err = parseSemanticVersion(part); err != nil {
+			matches := partialVersionPattern.FindStringSubmatch(part)
+			if matches == nil {
+				return nil, fmt.Errorf("%q is not a valid version constraint", strings.TrimSpace(value))
+			}
+			constraint.precision = 1
+			constraint.version.major, _ = strconv.ParseInt(matches[1], 10, 64)
+			if matches[2] != "" {
+				constraint.precision = 2
+				constraint.version.minor, _ = strconv.ParseInt(matches[2], 10, 64)
+			}
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
+
+// satisfiedBy reports whether the version satisfies the constraint. Versions are compared by SemVer precedence, so a
+// prerelease satisfies any range which includes it.
+func (c semanticVersionConstraint) satisfiedBy(version semanticVersion) bool {
+	result := version.compare(c.version)
+	switch c.operator {
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case "~>":
+		// Only the rightmost given component may increase, so `~> 1.2.3` allows 1.2.x and `~> 1.2` allows 1.x.
+		upper := semanticVersion{major: c.version.major + 1}
+		if c.precision == 3 {
+			upper = semanticVersion{major: c.version.major, minor: c.version.minor + 1}
+		}
+		return result >= 0 && version.compare(upper) < 0
+	}
+	return result == 0
+}
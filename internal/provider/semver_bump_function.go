@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strconv"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SemverBumpFunction{}
+
+func NewSemverBumpFunction() function.Function {
+	return &SemverBumpFunction{}
+}
+
+type SemverBumpFunction struct {
+}
+
+func (f SemverBumpFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "semver_bump"
+}
+
+func (f SemverBumpFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Increment a semantic version",
+		MarkdownDescription: "Returns the version which `counter_semantic_version` would produce after incrementing the given level. The `major`, `minor` and `patch` levels increment that component and reset the lower ones, `prerelease` increments the prerelease number, or moves to a later channel, and `release` promotes a prerelease to its final release. Build metadata is never carried over to the next version.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "version",
+				MarkdownDescription: "The version to increment.",
+			},
+			function.StringParameter{
+				Name:                "level",
+				MarkdownDescription: "One of `major`, `minor`, `patch`, `prerelease` or `release`.",
+			},
+		},
+		VariadicParameter: function.MapParameter{
+			Name:                "options",
+			ElementType:         types.StringType,
+			MarkdownDescription: "An optional map of `step`, the amount to increment by; `channel`, the prerelease channel of the next version, which is required to bump a release to a prerelease; `build_metadata`; `reset_lower_components`, `false` to keep the lower components; and `initial_development`, `true` to increment the minor version for a `major` bump while the major version is 0.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f SemverBumpFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value, level string
+	var options []map[string]string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value, &level, &options))
+	if resp.Error != nil {
+		return
+	}
+	if len(options) > 1 {
+		resp.Error = function.NewArgumentFuncError(3, "only one options map may be given")
+		return
+	}
+
+	version, err := parseSemanticVersion(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	data, channel, initialDevelopment, err := f.parseOptions(options)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+
+	s := SemanticVersionResource{}
+	var next semanticVersion
+	switch level {
+	case "major", "minor", "patch":
+		major, minor := level == "major", level == "minor"
+		if major && version.major == 0 && initialDevelopment {
+			major, minor = false, true
+		}
+		next = s.increment(data, version, major, minor, level == "patch")
+		if channel != "" {
+			next.prerelease = []string{channel, "1"}
+		}
+	case "prerelease":
+		switch {
+		case !version.isPrerelease() && channel == "":
+			resp.Error = function.NewArgumentFuncError(2, "a channel is required to bump a release to a prerelease")
+			return
+		case !version.isPrerelease():
+			// A release can't have prereleases of its own, so enter the channel for the next patch version.
+			next = s.increment(data, version, false, false, true)
+			next.prerelease = []string{channel, "1"}
+		case channel == "" || channel == version.channel():
+			next = s.nextPrerelease(version)
+		default:
+			next = semanticVersion{major: version.major, minor: version.minor, patch: version.patch, prerelease: []string{channel, "1"}}
+			if next.compare(version) <= 0 {
+				resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("the channel %q has a lower precedence than %q, so the version would go backwards", channel, version.channel()))
+				return
+			}
+		}
+	case "release":
+		next = semanticVersion{major: version.major, minor: version.minor, patch: version.patch}
+	default:
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%q must be one of major, minor, patch, prerelease or release", level))
+		return
+	}
+
+	next.build = nil
+	if metadata := data.BuildMetadata.ValueString(); metadata != "" {
+		next.build = strings.Split(metadata, ".")
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, next.String()))
+}
+
+// parseOptions converts the options map into the resource model used by SemanticVersionResource.increment, along with
+// the options which aren't part of it.
+func (f SemverBumpFunction) parseOptions(options []map[string]string) (semanticVersionModelV1, string, bool, error) {
+	data := semanticVersionModelV1{
+		MajorStep:            types.Int64Value(1),
+		MinorStep:            types.Int64Value(1),
+		PatchStep:            types.Int64Value(1),
+		ResetLowerComponents: types.MapNull(types.BoolType),
+		BuildMetadata:        types.StringNull(),
+	}
+	var channel string
+	var initialDevelopment bool
+	if len(options) == 0 {
+		return data, channel, initialDevelopment, nil
+	}
+
+	for key, value := range options[0] {
+		switch key {
+		case "step":
+			step, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || step < 1 {
+				return data, "", false, fmt.Errorf("the step %q must be a whole number of at least 1", value)
+			}
+			data.MajorStep = types.Int64Value(step)
+			data.MinorStep = types.Int64Value(step)
+			data.PatchStep = types.Int64Value(step)
+		case "channel":
+			if !prereleaseChannelPattern.MatchString(value) {
+				return data, "", false, fmt.Errorf("the channel %q must be an alphanumeric SemVer prerelease identifier", value)
+			}
+			channel = value
+		case "build_metadata":
+			if !buildMetadataPattern.MatchString(value) {
+				return data, "", false, fmt.Errorf("the build metadata %q must be dot separated alphanumeric SemVer build identifiers", value)
+			}
+			data.BuildMetadata = types.StringValue(value)
+		case "reset_lower_components":
+			reset, err := strconv.ParseBool(value)
+			if err != nil {
+				return data, "", false, fmt.Errorf("reset_lower_components must be true or false, got %q", value)
+			}
+			data.ResetLowerComponents = types.MapValueMust(types.BoolType, map[string]attr.Value{
+				"major": types.BoolValue(reset),
+				"minor": types.BoolValue(reset),
+			})
+		case "initial_development":
+			var err error
+			if initialDevelopment, err = strconv.ParseBool(value); err != nil {
+				return data, "", false, fmt.Errorf("initial_development must be true or false, got %q", value)
+			}
+		default:
+			return data, "", false, fmt.Errorf("unsupported option %q, expected one of: step, channel, build_metadata, reset_lower_components, initial_development", key)
+		}
+	}
+	return data, channel, initialDevelopment, nil
+}
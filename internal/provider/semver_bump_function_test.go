@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSemverBumpFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test levels
+			{
+				Config: `
+					output "major" {
+						value = provider::counter::semver_bump("1.2.3+sha.abc123", "major")
+					}
+					output "patch" {
+						value = provider::counter::semver_bump("1.2.3", "patch", { step = 10 })
+					}
+					output "initial_development" {
+						value = provider::counter::semver_bump("0.2.3", "major", { initial_development = true })
+					}
+					output "prerelease" {
+						value = provider::counter::semver_bump("1.2.3-beta.2", "prerelease")
+					}
+					output "channel" {
+						value = provider::counter::semver_bump("1.2.3", "prerelease", { channel = "rc", build_metadata = "sha.abc123" })
+					}
+					output "release" {
+						value = provider::counter::semver_bump("1.2.3-rc.2", "release")
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("major", "2.0.0"),
+					resource.TestCheckOutput("patch", "1.2.13"),
+					resource.TestCheckOutput("initial_development", "0.3.0"),
+					resource.TestCheckOutput("prerelease", "1.2.3-beta.3"),
+					resource.TestCheckOutput("channel", "1.2.4-rc.1+sha.abc123"),
+					resource.TestCheckOutput("release", "1.2.3"),
+				),
+			},
+			// Test moving back to an earlier channel
+			{
+				Config: `
+					output "backwards" {
+						value = provider::counter::semver_bump("1.2.3-beta.2", "prerelease", { channel = "alpha" })
+					}
+				`,
+				ExpectError: regexp.MustCompile(`the version would go backwards`),
+			},
+			// Test invalid level
+			{
+				Config: `
+					output "invalid" {
+						value = provider::counter::semver_bump("1.2.3", "build")
+					}
+				`,
+				ExpectError: regexp.MustCompile(`must be one of major, minor, patch, prerelease or release`),
+			},
+		},
+	})
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SemverCompareFunction{}
+
+func NewSemverCompareFunction() function.Function {
+	return &SemverCompareFunction{}
+}
+
+type SemverCompareFunction struct {
+}
+
+func (f SemverCompareFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "semver_compare"
+}
+
+func (f SemverCompareFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Compare two semantic versions",
+		MarkdownDescription: "Returns -1, 0 or 1 when the first version has a lower, equal or higher [SemVer precedence](https://semver.org/#spec-item-11) than the second. Build metadata is ignored.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "a",
+				MarkdownDescription: "The first version.",
+			},
+			function.StringParameter{
+				Name:                "b",
+				MarkdownDescription: "The second version.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f SemverCompareFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+
+	aVersion, err := parseSemanticVersion(a)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	bVersion, err := parseSemanticVersion(b)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, int64(aVersion.compare(bVersion))))
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSemverCompareFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test precedence
+			{
+				Config: `
+					output "lower" {
+						value = provider::counter::semver_compare("1.0.0-rc.1", "1.0.0")
+					}
+					output "equal" {
+						value = provider::counter::semver_compare("1.0.0+sha.abc123", "1.0.0+sha.def456")
+					}
+					output "higher" {
+						value = provider::counter::semver_compare("1.10.0", "1.9.0")
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("lower", "-1"),
+					resource.TestCheckOutput("equal", "0"),
+					resource.TestCheckOutput("higher", "1"),
+				),
+			},
+			// Test invalid version
+			{
+				Config: `
+					output "invalid" {
+						value = provider::counter::semver_compare("1.0.0", "v1.0.0")
+					}
+				`,
+				ExpectError: regexp.MustCompile(`not a valid semantic version`),
+			},
+		},
+	})
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SemverMaxFunction{}
+
+func NewSemverMaxFunction() function.Function {
+	return &SemverMaxFunction{}
+}
+
+type SemverMaxFunction struct {
+}
+
+func (f SemverMaxFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "semver_max"
+}
+
+func (f SemverMaxFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Find the highest semantic version",
+		MarkdownDescription: "Returns the version with the highest [SemVer precedence](https://semver.org/#spec-item-11) from a non-empty list. When several versions share the highest precedence, the first of them is returned.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "versions",
+				ElementType:         types.StringType,
+				MarkdownDescription: "The versions to choose from.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f SemverMaxFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var values []string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &values))
+	if resp.Error != nil {
+		return
+	}
+	if len(values) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, "at least one version is required")
+		return
+	}
+
+	versions, err := parseSemanticVersions(values)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	// Keep the first of several versions with equal precedence, as slices.MaxFunc does.
+	highest := slices.MaxFunc(versions, semanticVersion.compare)
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, highest.String()))
+}
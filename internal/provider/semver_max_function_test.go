@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSemverMaxFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test highest precedence
+			{
+				Config: `
+					output "latest" {
+						value = provider::counter::semver_max(["1.10.0", "1.2.0", "1.10.0-rc.1"])
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("latest", "1.10.0"),
+				),
+			},
+			// Test empty list
+			{
+				Config: `
+					output "empty" {
+						value = provider::counter::semver_max([])
+					}
+				`,
+				ExpectError: regexp.MustCompile(`at least one version is required`),
+			},
+		},
+	})
+}
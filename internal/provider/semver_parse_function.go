@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SemverParseFunction{}
+
+var semverParseReturnAttributeTypes = map[string]attr.Type{
+	"major":      types.Int64Type,
+	"minor":      types.Int64Type,
+	"patch":      types.Int64Type,
+	"prerelease": types.StringType,
+	"build":      types.StringType,
+}
+
+func NewSemverParseFunction() function.Function {
+	return &SemverParseFunction{}
+}
+
+type SemverParseFunction struct {
+}
+
+func (f SemverParseFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "semver_parse"
+}
+
+func (f SemverParseFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parse a semantic version",
+		MarkdownDescription: "Parses a [SemVer 2.0](https://semver.org) version into an object with `major`, `minor` and `patch` numbers, and the `prerelease` and `build` strings, which are null when the version doesn't have them.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "version",
+				MarkdownDescription: "The version to parse, such as `1.2.3-beta.1+sha.abc123`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: semverParseReturnAttributeTypes,
+		},
+	}
+}
+
+func (f SemverParseFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	version, err := parseSemanticVersion(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	prerelease := types.StringNull()
+	if version.isPrerelease() {
+		prerelease = types.StringValue(strings.Join(version.prerelease, "."))
+	}
+	build := types.StringNull()
+	if len(version.build) > 0 {
+		build = types.StringValue(strings.Join(version.build, "."))
+	}
+	result := types.ObjectValueMust(semverParseReturnAttributeTypes, map[string]attr.Value{
+		"major":      types.Int64Value(version.major),
+		"minor":      types.Int64Value(version.minor),
+		"patch":      types.Int64Value(version.patch),
+		"prerelease": prerelease,
+		"build":      build,
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSemverParseFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test all components
+			{
+				Config: `
+					locals {
+						version = provider::counter::semver_parse("1.2.3-beta.1+sha.abc123")
+					}
+					output "major" {
+						value = local.version.major
+					}
+					output "minor" {
+						value = local.version.minor
+					}
+					output "patch" {
+						value = local.version.patch
+					}
+					output "prerelease" {
+						value = local.version.prerelease
+					}
+					output "build" {
+						value = local.version.build
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("major", "1"),
+					resource.TestCheckOutput("minor", "2"),
+					resource.TestCheckOutput("patch", "3"),
+					resource.TestCheckOutput("prerelease", "beta.1"),
+					resource.TestCheckOutput("build", "sha.abc123"),
+				),
+			},
+			// Test release without build metadata
+			{
+				Config: `
+					output "release" {
+						value = provider::counter::semver_parse("1.2.3").prerelease == null
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("release", "true"),
+				),
+			},
+			// Test invalid version
+			{
+				Config: `
+					output "invalid" {
+						value = provider::counter::semver_parse("1.2")
+					}
+				`,
+				ExpectError: regexp.MustCompile(`not a valid semantic version`),
+			},
+		},
+	})
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SemverSatisfiesFunction{}
+
+func NewSemverSatisfiesFunction() function.Function {
+	return &SemverSatisfiesFunction{}
+}
+
+type SemverSatisfiesFunction struct {
+}
+
+func (f SemverSatisfiesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "semver_satisfies"
+}
+
+func (f SemverSatisfiesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check a semantic version against constraints",
+		MarkdownDescription: "Returns whether a version satisfies every comma separated constraint, such as `>= 1.2.0, < 2.0.0`. The operators `=`, `!=`, `>`, `>=`, `<`, `<=` and `~>` are supported, where `~>` allows only the rightmost given component to increase, so `~> 1.2.3` allows `1.2.x` and `~> 1.2` allows `1.x`. Versions in constraints may omit the minor and patch components, which default to 0. Versions are compared by [SemVer precedence](https://semver.org/#spec-item-11), so a prerelease satisfies any range which includes it.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "version",
+				MarkdownDescription: "The version to check.",
+			},
+			function.StringParameter{
+				Name:                "constraint",
+				MarkdownDescription: "The constraints the version must satisfy.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f SemverSatisfiesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value, constraint string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value, &constraint))
+	if resp.Error != nil {
+		return
+	}
+
+	version, err := parseSemanticVersion(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	constraints, err := parseSemanticVersionConstraints(constraint)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	satisfied := true
+	for _, c := range constraints {
+		satisfied = satisfied && c.satisfiedBy(version)
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, satisfied))
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSemverSatisfiesFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test constraints
+			{
+				Config: `
+					output "range" {
+						value = provider::counter::semver_satisfies("1.5.0", ">= 1.2, < 2")
+					}
+					output "pessimistic_patch" {
+						value = provider::counter::semver_satisfies("1.3.0", "~> 1.2.3")
+					}
+					output "pessimistic_minor" {
+						value = provider::counter::semver_satisfies("1.9.0", "~> 1.2")
+					}
+					output "excluded" {
+						value = provider::counter::semver_satisfies("1.4.0", "~> 1.2, != 1.4.0")
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("range", "true"),
+					resource.TestCheckOutput("pessimistic_patch", "false"),
+					resource.TestCheckOutput("pessimistic_minor", "true"),
+					resource.TestCheckOutput("excluded", "false"),
+				),
+			},
+			// Test invalid constraint
+			{
+				Config: `
+					output "invalid" {
+						value = provider::counter::semver_satisfies("1.5.0", ">= one")
+					}
+				`,
+				ExpectError: regexp.MustCompile(`not a valid version constraint`),
+			},
+		},
+	})
+}
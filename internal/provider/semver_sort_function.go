@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SemverSortFunction{}
+
+func NewSemverSortFunction() function.Function {
+	return &SemverSortFunction{}
+}
+
+type SemverSortFunction struct {
+}
+
+func (f SemverSortFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "semver_sort"
+}
+
+func (f SemverSortFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Sort semantic versions",
+		MarkdownDescription: "Sorts a list of versions into ascending [SemVer precedence](https://semver.org/#spec-item-11). Versions with equal precedence, such as those differing only in build metadata, keep their order.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "versions",
+				ElementType:         types.StringType,
+				MarkdownDescription: "The versions to sort.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f SemverSortFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var values []string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &values))
+	if resp.Error != nil {
+		return
+	}
+
+	versions, err := parseSemanticVersions(values)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	slices.SortStableFunc(versions, semanticVersion.compare)
+
+	sorted := make([]string, 0, len(versions))
+	for _, version := range versions {
+		sorted = append(sorted, version.String())
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, sorted))
+}
+
+// parseSemanticVersions parses a list of function arguments, identifying any invalid version by its index.
+func parseSemanticVersions(values []string) ([]semanticVersion, error) {
+	versions := make([]semanticVersion, 0, len(values))
+	for i, value := range values {
+		version, err := parseSemanticVersion(value)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSemverSortFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test precedence order
+			{
+				Config: `
+					output "sorted" {
+						value = join(",", provider::counter::semver_sort(["1.10.0", "1.2.0", "1.0.0-alpha.10", "1.0.0-alpha.2", "1.0.0"]))
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("sorted", "1.0.0-alpha.2,1.0.0-alpha.10,1.0.0,1.2.0,1.10.0"),
+				),
+			},
+			// Test invalid version
+			{
+				Config: `
+					output "invalid" {
+						value = provider::counter::semver_sort(["1.0.0", "latest"])
+					}
+				`,
+				ExpectError: regexp.MustCompile(`element 1`),
+			},
+		},
+	})
+}
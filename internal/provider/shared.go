@@ -2,28 +2,290 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"slices"
+	"strings"
+	"time"
 )
 
-func truncate(list []basetypes.ObjectValue, maximum int64) []basetypes.ObjectValue {
+const defaultMaxHistory = 1000
+
+// importedPrivateStateKey marks state written by `terraform import` which has not been applied yet. Its history is
+// seeded with the imported value, and the first plan after the import adopts the configured triggers into that entry
+// instead of incrementing. A plan without changes leaves the state, including this mark, as it was, so the mark is
+// cleared by the first update and the first triggers configured for an imported resource are adopted.
+const importedPrivateStateKey = "imported"
+
+// privateState is the subset of the framework's private state data used by the resources in this provider.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func truncate(list []attr.Value, maximum int64) []attr.Value {
+	if maximum < 0 {
+		maximum = 0
+	}
 	if int64(len(list)) > maximum {
 		return list[(int64(len(list)) - maximum):]
 	}
 	return list
 }
 
-func appendAndTruncate(list []basetypes.ObjectValue, item basetypes.ObjectValue, maximum int64) []basetypes.ObjectValue {
-	return truncate(append(list, item), maximum)
+func appendAndTruncate(ctx context.Context, history types.List, item basetypes.ObjectValue, maximum int64) types.List {
+	list := append(slices.Clone(history.Elements()), item)
+	return types.ListValueMust(history.ElementType(ctx), truncate(list, maximum))
+}
+
+func replaceLastAndTruncate(ctx context.Context, history types.List, item basetypes.ObjectValue, maximum int64) types.List {
+	list := history.Elements()
+	if len(list) > 0 {
+		list = list[:len(list)-1]
+	}
+	return appendAndTruncate(ctx, types.ListValueMust(history.ElementType(ctx), list), item, maximum)
+}
+
+// currentTime returns the time from a clock set by the provider configuration, falling back to the system time when
+// the provider hasn't been configured, in UTC.
+func currentTime(now func() time.Time) time.Time {
+	if now == nil {
+		return time.Now().UTC()
+	}
+	return now().UTC()
+}
+
+// parseImportId splits an import identifier into attribute values. The identifier is either a bare value, which is
+// assigned to defaultAttribute, or a comma separated list of `attribute=value` pairs using the allowed attributes.
+func parseImportId(id string, defaultAttribute string, allowed ...string) (map[string]string, error) {
+	id = strings.TrimSpace(id)
+	if !strings.Contains(id, "=") {
+		if id == "" {
+			return nil, fmt.Errorf("the import identifier must not be empty")
+		}
+		return map[string]string{defaultAttribute: id}, nil
+	}
+
+	values := map[string]string{}
+	for _, pair := range strings.Split(id, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if !found || key == "" || value == "" {
+			return nil, fmt.Errorf("expected `attribute=value`, got %q", pair)
+		}
+		if !slices.Contains(allowed, key) {
+			return nil, fmt.Errorf("unsupported attribute %q, expected one of: %s", key, strings.Join(allowed, ", "))
+		}
+		if _, duplicate := values[key]; duplicate {
+			return nil, fmt.Errorf("attribute %q is specified more than once", key)
+		}
+		values[key] = value
+	}
+
+	if _, ok := values[defaultAttribute]; !ok {
+		return nil, fmt.Errorf("the import identifier must include %q", defaultAttribute)
+	}
+	return values, nil
+}
+
+func markImported(ctx context.Context, private privateState) diag.Diagnostics {
+	return private.SetKey(ctx, importedPrivateStateKey, []byte("true"))
+}
+
+func clearImported(ctx context.Context, private privateState) diag.Diagnostics {
+	return private.SetKey(ctx, importedPrivateStateKey, nil)
+}
+
+func isImported(ctx context.Context, private privateState) (bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, importedPrivateStateKey)
+	return len(value) > 0, diags
+}
+
+var _ resource.ConfigValidator = triggersConfiguredValidator{}
+
+// triggersConfiguredValidator warns when none of the given trigger attributes are configured, as the resource would
+// then never change after creation.
+type triggersConfiguredValidator struct {
+	attributes []string
+}
+
+func triggersConfigured(attributes ...string) resource.ConfigValidator {
+	return triggersConfiguredValidator{attributes: attributes}
+}
+
+func (v triggersConfiguredValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("at least one of %s should be configured", strings.Join(v.attributes, ", "))
+}
+
+func (v triggersConfiguredValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v triggersConfiguredValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	for _, attribute := range v.attributes {
+		var triggers types.Map
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &triggers)...)
+		if !triggers.IsNull() {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddWarning(
+		"No Triggers Configured",
+		fmt.Sprintf("None of %s are configured, so the value will never change after it is created.", strings.Join(v.attributes, ", ")),
+	)
+}
+
+// findHistoryEntry returns the most recent history entry whose attributes equal all the given values.
+func findHistoryEntry(history types.List, attributes map[string]attr.Value) (basetypes.ObjectValue, bool) {
+	return findHistoryEntryFunc(history, func(entry basetypes.ObjectValue) bool {
+		for name, value := range attributes {
+			if !value.Equal(entry.Attributes()[name]) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// findHistoryEntryFunc returns the most recent history entry for which matches returns true.
+func findHistoryEntryFunc(history types.List, matches func(entry basetypes.ObjectValue) bool) (basetypes.ObjectValue, bool) {
+	entries := history.Elements()
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry, ok := entries[i].(basetypes.ObjectValue)
+		if ok && matches(entry) {
+			return entry, true
+		}
+	}
+	return basetypes.ObjectValue{}, false
+}
+
+// historyContains reports whether any history entry holds the given value for an attribute.
+func historyContains(history types.List, attribute string, value attr.Value) bool {
+	_, found := findHistoryEntry(history, map[string]attr.Value{attribute: value})
+	return found
 }
 
-func mapAttributeIsEqual(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attribute string) bool {
-	var state types.Map
-	var plan types.Map
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attribute), &plan)...)
-	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &state)...)
+// mapFullyKnown reports whether a map and all of its elements are known. A map of triggers such as
+// `{hash = md5(...)}` is known while planning even when the value of its element isn't.
+func mapFullyKnown(m types.Map) bool {
+	if m.IsUnknown() {
+		return false
+	}
+	for _, element := range m.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
 
-	return state.Equal(plan)
+// setFullyKnown reports whether a set and all of its elements are known, as a set of values derived from resources
+// which are being replaced can be partially unknown while planning.
+func setFullyKnown(set types.Set) bool {
+	if set.IsUnknown() {
+		return false
+	}
+	for _, element := range set.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// listFullyKnown reports whether a list and all of its elements are known.
+func listFullyKnown(list types.List) bool {
+	if list.IsUnknown() {
+		return false
+	}
+	for _, element := range list.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// triggeredLifecycle shares the plan and apply handling of resources whose values change when their triggers do. The
+// resource provides how its values are initialised on creation and advanced from the prior state, and the lifecycle
+// handles destruction, inputs which aren't known while planning, and the first plan after an import.
+type triggeredLifecycle[M any] struct {
+	// known reports whether every input which decides the values is known.
+	known func(data M) bool
+	// unknown sets the values which can't be planned until the inputs are known.
+	unknown func(data *M, creation bool)
+	// initialise sets the values and history of a resource which is being created.
+	initialise func(ctx context.Context, data *M) diag.Diagnostics
+	// advance sets the values and history from the prior state. The values of an imported resource are kept, and the
+	// history entry seeded by the import is replaced with one for the configured triggers, with replaceLastAndTruncate.
+	advance func(ctx context.Context, prior M, data *M, imported bool) diag.Diagnostics
+	// settled optionally reports whether the plan already settled the values, which are then applied as planned rather
+	// than advanced again. Values which depend on the time must not change between planning and applying.
+	settled func(data M) bool
+}
+
+func (l triggeredLifecycle[M]) modifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data M
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	creation := req.State.Raw.IsNull()
+
+	// Whether the values change can't be known until the inputs are, for example when the triggers are derived from a
+	// resource which is being replaced. Create and Update settle the values once the inputs are known.
+	if !l.known(data) {
+		l.unknown(&data, creation)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+		return
+	}
+
+	if creation {
+		resp.Diagnostics.Append(l.initialise(ctx, &data)...)
+	} else {
+		var prior M
+		resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+		imported, diags := isImported(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(l.advance(ctx, prior, &data, imported)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+func (l triggeredLifecycle[M]) update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data M
+	var prior M
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	imported, diags := isImported(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if l.settled == nil || !l.settled(data) {
+		resp.Diagnostics.Append(l.advance(ctx, prior, &data, imported)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(clearImported(ctx, resp.Private)...)
 }
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"slices"
+	"strconv"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ToggleResource{}
+var _ resource.ResourceWithModifyPlan = &ToggleResource{}
+var _ resource.ResourceWithImportState = &ToggleResource{}
+var _ resource.ResourceWithConfigValidators = &ToggleResource{}
+
+// defaultToggleSlots are the slots of a blue/green deployment.
+var defaultToggleSlots = []string{"blue", "green"}
+
+func NewToggleResource() resource.Resource {
+	return &ToggleResource{}
+}
+
+type ToggleResource struct {
+}
+
+func defaultToggleSlotsValue() types.List {
+	var slots []attr.Value
+	for _, slot := range defaultToggleSlots {
+		slots = append(slots, types.StringValue(slot))
+	}
+	return types.ListValueMust(types.StringType, slots)
+}
+
+func (t ToggleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_toggle"
+}
+
+func (t ToggleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A toggle which moves to the next of its slots, such as from `blue` to `green` and back, each time the triggers change, for blue/green deployments and cutovers.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current slot.",
+			},
+			"index": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The index of the current slot in `slots`.",
+			},
+			"active": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current slot, which should receive traffic. The same as `value`.",
+			},
+			"inactive": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The slot which becomes active the next time the triggers change, which should receive the next deployment.",
+			},
+			"slots": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Optional:            true,
+				Default:             listdefault.StaticValue(defaultToggleSlotsValue()),
+				MarkdownDescription: "The slots to move through in order, starting with the first. Must contain at least 2 unique slots, and the current slot can't be removed. Defaults to `blue` and `green`.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(2),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"max_history": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(defaultMaxHistory),
+				MarkdownDescription: "Maximum number of slots this resource should store in the `history` attribute. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"history": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "A list of slots that this resource has activated, for auditing cutovers.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+						"triggers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of strings that will cause the toggle to move to the next slot when any of the values change.",
+			},
+		},
+	}
+}
+
+func (t ToggleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		triggersConfigured("triggers"),
+	}
+}
+
+func (t ToggleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data toggleModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(uuid.New().String())
+	resp.Diagnostics.Append(t.initialise(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (t ToggleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.State = req.State
+}
+
+func (t ToggleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	t.lifecycle().update(ctx, req, resp)
+}
+
+func (t ToggleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (t ToggleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	t.lifecycle().modifyPlan(ctx, req, resp)
+}
+
+func (t ToggleResource) lifecycle() triggeredLifecycle[toggleModelV0] {
+	return triggeredLifecycle[toggleModelV0]{
+		known:      t.inputsKnown,
+		unknown:    t.setUnknownSlot,
+		initialise: t.initialise,
+		advance:    t.advance,
+	}
+}
+
+func (t ToggleResource) inputsKnown(data toggleModelV0) bool {
+	return mapFullyKnown(data.Triggers) && listFullyKnown(data.Slots) && !data.MaxHistory.IsUnknown()
+}
+
+func (t ToggleResource) setUnknownSlot(data *toggleModelV0, creation bool) {
+	data.Value = types.StringUnknown()
+	data.Index = types.Int64Unknown()
+	data.Active = types.StringUnknown()
+	data.Inactive = types.StringUnknown()
+	data.History = types.ListUnknown(toggleHistoryEntryType)
+}
+
+// initialise activates the first slot of a toggle which is being created.
+func (t ToggleResource) initialise(ctx context.Context, data *toggleModelV0) diag.Diagnostics {
+	var slots []string
+	diags := data.Slots.ElementsAs(ctx, &slots, false)
+	if diags.HasError() {
+		return diags
+	}
+	t.setSlot(data, slots, 0)
+	data.History = appendAndTruncate(ctx, types.ListNull(toggleHistoryEntryType), t.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	return diags
+}
+
+// advance sets the slot and history from the prior state, moving to the next slot when the triggers changed. The
+// current slot is found by its name, so slots can be added, removed or reordered as long as it remains.
+func (t ToggleResource) advance(ctx context.Context, prior toggleModelV0, data *toggleModelV0, imported bool) diag.Diagnostics {
+	var slots []string
+	diags := data.Slots.ElementsAs(ctx, &slots, false)
+	if diags.HasError() {
+		return diags
+	}
+	index := slices.Index(slots, prior.Value.ValueString())
+	if index < 0 {
+		diags.AddAttributeError(
+			path.Root("slots"),
+			"Active Slot Removed",
+			fmt.Sprintf("The active slot %q must remain in slots so that the next slot can be found. Remove it after the next change of triggers instead.", prior.Value.ValueString()),
+		)
+		return diags
+	}
+	t.setSlot(data, slots, index)
+	data.History = prior.History
+
+	if imported {
+		data.History = replaceLastAndTruncate(ctx, prior.History, t.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+		return diags
+	}
+
+	if !prior.Triggers.Equal(data.Triggers) {
+		t.setSlot(data, slots, (index+1)%len(slots))
+		data.History = appendAndTruncate(ctx, prior.History, t.createHistoryEntry(*data), data.MaxHistory.ValueInt64())
+	}
+	return diags
+}
+
+func (t ToggleResource) setSlot(data *toggleModelV0, slots []string, index int) {
+	data.Value = types.StringValue(slots[index])
+	data.Index = types.Int64Value(int64(index))
+	data.Active = data.Value
+	data.Inactive = types.StringValue(slots[(index+1)%len(slots)])
+}
+
+func (t ToggleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := parseImportId(req.ID, "value", "value", "max_history")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("Unable to parse %q: %s. Expected the active slot such as `green`, or `value=green,max_history=10`.", req.ID, err))
+		return
+	}
+
+	data := toggleModelV0{
+		Id:         types.StringValue(uuid.New().String()),
+		Slots:      defaultToggleSlotsValue(),
+		MaxHistory: types.Int64Value(defaultMaxHistory),
+		Triggers:   types.MapNull(types.StringType),
+	}
+	if raw, ok := values["max_history"]; ok {
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || number < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_history"), "Invalid Import Identifier", fmt.Sprintf("The value %q for max_history must be a whole number of at least 1.", raw))
+			return
+		}
+		data.MaxHistory = types.Int64Value(number)
+	}
+
+	// The slots are taken from the configuration, so the position of the active slot is settled by the first plan.
+	if index := slices.Index(defaultToggleSlots, values["value"]); index >= 0 {
+		t.setSlot(&data, defaultToggleSlots, index)
+	} else {
+		data.Value = types.StringValue(values["value"])
+		data.Index = types.Int64Null()
+		data.Active = data.Value
+		data.Inactive = types.StringNull()
+	}
+	data.History = appendAndTruncate(ctx, types.ListNull(toggleHistoryEntryType), t.createHistoryEntry(data), data.MaxHistory.ValueInt64())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private)...)
+}
+
+var toggleHistoryEntryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"value":    types.StringType,
+		"triggers": types.MapType{ElemType: types.StringType},
+	},
+}
+
+func (t ToggleResource) createHistoryEntry(data toggleModelV0) basetypes.ObjectValue {
+	return types.ObjectValueMust(
+		toggleHistoryEntryType.AttrTypes,
+		map[string]attr.Value{
+			"value":    data.Value,
+			"triggers": data.Triggers,
+		},
+	)
+}
+
+type toggleModelV0 struct {
+	Id         types.String `tfsdk:"id"`
+	Value      types.String `tfsdk:"value"`
+	Index      types.Int64  `tfsdk:"index"`
+	Active     types.String `tfsdk:"active"`
+	Inactive   types.String `tfsdk:"inactive"`
+	Slots      types.List   `tfsdk:"slots"`
+	MaxHistory types.Int64  `tfsdk:"max_history"`
+	History    types.List   `tfsdk:"history"`
+	Triggers   types.Map    `tfsdk:"triggers"`
+}
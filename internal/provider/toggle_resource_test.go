@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"regexp"
+	"testing"
+)
+
+func toggleStep(hash string) string {
+	return `
+		resource counter_toggle this {
+			triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccToggleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: toggleStep("potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_toggle.this", "value", "blue"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "active", "blue"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "inactive", "green"),
+				),
+			},
+			{
+				Config: toggleStep("eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_toggle.this", "active", "green"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "inactive", "blue"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "index", "1"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "history.#", "2"),
+				),
+			},
+			{
+				Config: toggleStep("bacon"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_toggle.this", "active", "blue"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "history.2.value", "blue"),
+				),
+			},
+		},
+	})
+}
+
+func toggleSlotsStep(slots string, hash string) string {
+	return `
+		resource counter_toggle this {
+			slots = [` + slots + `]
+			triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccToggleResourceSlots(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: toggleSlotsStep(`"a", "b", "c"`, "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_toggle.this", "active", "a"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "inactive", "b"),
+				),
+			},
+			// Adding a slot doesn't move the toggle
+			{
+				Config: toggleSlotsStep(`"a", "d", "b", "c"`, "potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_toggle.this", "active", "a"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "inactive", "d"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "history.#", "1"),
+				),
+			},
+			{
+				Config:      toggleSlotsStep(`"d", "b", "c"`, "potatoes"),
+				ExpectError: regexp.MustCompile(`The active slot "a" must remain in slots`),
+			},
+			{
+				Config:      toggleSlotsStep(`"a"`, "potatoes"),
+				ExpectError: regexp.MustCompile(`must contain at least 2 elements`),
+			},
+		},
+	})
+}
+
+func TestAccToggleResourceImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: toggleStep("potatoes"),
+			},
+			{
+				Config:             toggleStep("potatoes"),
+				ResourceName:       "counter_toggle.this",
+				ImportState:        true,
+				ImportStateId:      "green",
+				ImportStatePersist: true,
+			},
+			// The first apply after import adopts the triggers without moving
+			{
+				Config: toggleStep("potatoes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_toggle.this", "active", "green"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "history.#", "1"),
+				),
+			},
+			{
+				Config: toggleStep("eggs"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_toggle.this", "active", "blue"),
+				),
+			},
+		},
+	})
+}
+
+func toggleUnknownSlotStep(hash string, replace string) string {
+	return `
+		resource terraform_data source {
+			input            = "green"
+			triggers_replace = ["` + replace + `"]
+		}
+
+		resource counter_toggle this {
+			slots = ["blue", terraform_data.source.output]
+			triggers = {
+				hash = "` + hash + `"
+			}
+		}
+	`
+}
+
+func TestAccToggleResourceUnknownSlots(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: toggleUnknownSlotStep("potatoes", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_toggle.this", "value", "blue"),
+				),
+			},
+			// Replacing the source makes one of the slots unknown during plan, so the next slot can't be planned until
+			// it is known
+			{
+				Config: toggleUnknownSlotStep("eggs", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("counter_toggle.this", tfjsonpath.New("value")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("counter_toggle.this", "value", "green"),
+					resource.TestCheckResourceAttr("counter_toggle.this", "history.#", "2"),
+				),
+			},
+		},
+	})
+}